@@ -1,10 +1,17 @@
 package controllers
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
 
 	S "github.com/atharvbhadange/go-api-template/api/v1/services"
+	C "github.com/atharvbhadange/go-api-template/constants"
 	H "github.com/atharvbhadange/go-api-template/handler"
+	M "github.com/atharvbhadange/go-api-template/models"
 	U "github.com/atharvbhadange/go-api-template/utils"
 )
 
@@ -15,15 +22,65 @@ func GetProducts(ctx *fiber.Ctx) error {
 		return H.BuildError(ctx, "Unable to get transaction", fiber.StatusInternalServerError, txErr)
 	}
 
-	products, serviceErr := S.GetProducts(dbTrx, ctx.UserContext())
+	if fields := ctx.Query("fields"); fields != "" {
+		products, meta, serviceErr := S.GetProductsWithFieldsWithMeta(dbTrx, ctx.UserContext(), strings.Split(fields, ","))
+
+		if serviceErr != nil {
+			return H.BuildServiceError(ctx, serviceErr)
+		}
+
+		return H.Success(ctx, fiber.Map{
+			"ok":       1,
+			"products": products,
+			"meta":     meta,
+		})
+	}
+
+	products, meta, serviceErr := S.GetProductsWithMeta(dbTrx, ctx.UserContext())
 
 	if serviceErr != nil {
-		return H.BuildError(ctx, serviceErr.Message, serviceErr.Code, serviceErr.Error)
+		return H.BuildServiceError(ctx, serviceErr)
 	}
 
 	return H.Success(ctx, fiber.Map{
 		"ok":       1,
 		"products": products,
+		"meta":     meta,
+	})
+}
+
+// ListProducts is the paginated, filterable product listing. It accepts page,
+// page_size, sort, name, min_price and max_price query parameters.
+func ListProducts(ctx *fiber.Ctx) error {
+	dbTrx, txErr := U.StartNewPGTrx(ctx)
+
+	if txErr != nil {
+		return H.BuildError(ctx, "Unable to get transaction", fiber.StatusInternalServerError, txErr)
+	}
+
+	filter, err := parseProductFilter(ctx)
+
+	if err != nil {
+		return H.BuildError(ctx, "Invalid filter", fiber.StatusBadRequest, err)
+	}
+
+	page, serviceErr := S.GetProductsPaginated(dbTrx, ctx.UserContext(), filter)
+
+	if serviceErr != nil {
+		return H.BuildServiceError(ctx, serviceErr)
+	}
+
+	headers, err := H.BuildPaginationLinks(ctx.BaseURL()+ctx.OriginalURL(), page)
+
+	if err != nil {
+		return H.BuildError(ctx, "Unable to build pagination links", fiber.StatusInternalServerError, err)
+	}
+
+	H.SetPaginationHeaders(ctx, headers)
+
+	return H.Success(ctx, fiber.Map{
+		"ok":   1,
+		"page": page,
 	})
 }
 
@@ -40,15 +97,59 @@ func GetProduct(ctx *fiber.Ctx) error {
 		return H.BuildError(ctx, "Invalid product id", fiber.StatusBadRequest, err)
 	}
 
+	if fields := ctx.Query("fields"); fields != "" {
+		product, serviceErr := S.GetProductWithFields(dbTrx, ctx.UserContext(), idInt, strings.Split(fields, ","))
+
+		if serviceErr != nil {
+			return H.BuildServiceError(ctx, serviceErr)
+		}
+
+		return H.Success(ctx, fiber.Map{
+			"ok":      1,
+			"product": product,
+		})
+	}
+
 	product, serviceErr := S.GetProduct(dbTrx, ctx.UserContext(), idInt)
 
 	if serviceErr != nil {
-		return H.BuildError(ctx, serviceErr.Message, serviceErr.Code, serviceErr.Error)
+		return H.BuildServiceError(ctx, serviceErr)
 	}
 
-	return H.Success(ctx, fiber.Map{
+	response := fiber.Map{
 		"ok":      1,
 		"product": product,
+	}
+
+	if lang := preferredLanguage(ctx); lang != "" {
+		response["display_price"] = S.FormatPriceForLocale(product.Price, C.DEFAULT_CURRENCY, lang)
+	}
+
+	return H.Success(ctx, response)
+}
+
+func CompareProducts(ctx *fiber.Ctx) error {
+	dbTrx, txErr := U.StartNewPGTrx(ctx)
+
+	if txErr != nil {
+		return H.BuildError(ctx, "Unable to get transaction", fiber.StatusInternalServerError, txErr)
+	}
+
+	ids, serviceErr := S.ParseProductIDs(ctx.Query("ids"))
+
+	if serviceErr != nil {
+		return H.BuildServiceError(ctx, serviceErr)
+	}
+
+	comparison, serviceErr := S.CompareProducts(dbTrx, ctx.UserContext(), ids)
+
+	if serviceErr != nil {
+		return H.BuildServiceError(ctx, serviceErr)
+	}
+
+	return H.Success(ctx, fiber.Map{
+		"ok":         1,
+		"comparison": comparison,
 	})
 }
 
@@ -65,15 +166,61 @@ func CreateProduct(ctx *fiber.Ctx) error {
 		return H.BuildError(ctx, "Invalid body", fiber.StatusBadRequest, err)
 	}
 
-	product, serviceErr := S.CreateProduct(dbTrx, ctx.UserContext(), body)
+	product, created, serviceErr := S.CreateOrGetProduct(dbTrx, ctx.UserContext(), body)
 
 	if serviceErr != nil {
-		return H.BuildError(ctx, serviceErr.Message, serviceErr.Code, serviceErr.Error)
+		return H.BuildServiceError(ctx, serviceErr)
 	}
 
-	return H.Success(ctx, fiber.Map{
+	response := fiber.Map{
 		"ok":      1,
 		"product": product,
+	}
+
+	if lang := preferredLanguage(ctx); lang != "" {
+		response["display_price"] = S.FormatPriceForLocale(product.Price, C.DEFAULT_CURRENCY, lang)
+	}
+
+	return H.SuccessAfterCommit(ctx, response, func() {
+		if created {
+			S.RunProductCreatedHooks(ctx.UserContext(), product)
+		}
+	})
+}
+
+// BulkCreateProducts creates every product in the body array. With
+// ?partial=true invalid items are skipped instead of failing the request.
+func BulkCreateProducts(ctx *fiber.Ctx) error {
+	dbTrx, txErr := U.StartNewPGTrx(ctx)
+
+	if txErr != nil {
+		return H.BuildError(ctx, "Unable to get transaction", fiber.StatusInternalServerError, txErr)
+	}
+
+	bodies := []*S.ProductBody{}
+
+	if err := ctx.BodyParser(&bodies); err != nil {
+		return H.BuildError(ctx, "Invalid body", fiber.StatusBadRequest, err)
+	}
+
+	results, serviceErr := S.BulkCreateProducts(dbTrx, ctx.UserContext(), bodies, ctx.QueryBool("partial"))
+
+	if serviceErr != nil {
+		if results != nil {
+			return H.BuildServiceErrorWithMessage(ctx, serviceErr, fiber.Map{"summary": serviceErr.Message, "results": results})
+		}
+		return H.BuildServiceError(ctx, serviceErr)
+	}
+
+	return H.SuccessAfterCommit(ctx, fiber.Map{
+		"ok":      1,
+		"results": results,
+	}, func() {
+		for _, result := range results {
+			if result.Product != nil {
+				S.RunProductCreatedHooks(ctx.UserContext(), result.Product)
+			}
+		}
 	})
 }
 
@@ -90,21 +237,42 @@ func UpdateProduct(ctx *fiber.Ctx) error {
 		return H.BuildError(ctx, "Invalid product id", fiber.StatusBadRequest, err)
 	}
 
+	if strings.HasPrefix(ctx.Get(fiber.HeaderContentType), "application/merge-patch+json") {
+		product, changes, watches, serviceErr := S.MergePatchProductWithMeta(dbTrx, ctx.UserContext(), idInt, ctx.Body(), ctx.QueryBool("force"))
+
+		if serviceErr != nil {
+			return H.BuildServiceError(ctx, serviceErr)
+		}
+
+		return H.SuccessAfterCommit(ctx, fiber.Map{
+			"ok":      1,
+			"product": product,
+			"changes": changes,
+		}, func() {
+			S.RunProductUpdatedHooks(ctx.UserContext(), product)
+			S.RunPriceWatchHooks(ctx.UserContext(), product, watches)
+		})
+	}
+
 	body := &S.ProductBody{}
 
 	if err := ctx.BodyParser(body); err != nil {
 		return H.BuildError(ctx, "Invalid body", fiber.StatusBadRequest, err)
 	}
 
-	product, serviceErr := S.UpdateProduct(dbTrx, ctx.UserContext(), idInt, body)
+	product, changes, watches, serviceErr := S.UpdateProductWithMeta(dbTrx, ctx.UserContext(), idInt, body, ctx.QueryBool("force"))
 
 	if serviceErr != nil {
-		return H.BuildError(ctx, serviceErr.Message, serviceErr.Code, serviceErr.Error)
+		return H.BuildServiceError(ctx, serviceErr)
 	}
 
-	return H.Success(ctx, fiber.Map{
+	return H.SuccessAfterCommit(ctx, fiber.Map{
 		"ok":      1,
 		"product": product,
+		"changes": changes,
+	}, func() {
+		S.RunProductUpdatedHooks(ctx.UserContext(), product)
+		S.RunPriceWatchHooks(ctx.UserContext(), product, watches)
 	})
 }
 
@@ -121,13 +289,82 @@ func DeleteProduct(ctx *fiber.Ctx) error {
 		return H.BuildError(ctx, "Invalid product id", fiber.StatusBadRequest, err)
 	}
 
+	if ctx.QueryBool("if_exists") {
+		deleted, serviceErr := S.DeleteProductIfExists(dbTrx, ctx.UserContext(), idInt)
+
+		if serviceErr != nil {
+			return H.BuildServiceError(ctx, serviceErr)
+		}
+
+		return H.SuccessAfterCommit(ctx, fiber.Map{
+			"ok":      1,
+			"deleted": deleted,
+		}, func() {
+			if deleted {
+				S.RunProductDeletedHooks(ctx.UserContext(), &M.Product{ID: idInt})
+			}
+		})
+	}
+
 	serviceErr := S.DeleteProduct(dbTrx, ctx.UserContext(), idInt)
 
 	if serviceErr != nil {
-		return H.BuildError(ctx, serviceErr.Message, serviceErr.Code, serviceErr.Error)
+		return H.BuildServiceError(ctx, serviceErr)
 	}
 
-	return H.Success(ctx, fiber.Map{
+	return H.SuccessAfterCommit(ctx, fiber.Map{
 		"ok": 1,
+	}, func() {
+		S.RunProductDeletedHooks(ctx.UserContext(), &M.Product{ID: idInt})
 	})
 }
+
+// preferredLanguage returns the first language tag from the Accept-Language header.
+func preferredLanguage(ctx *fiber.Ctx) string {
+	header := ctx.Get(fiber.HeaderAcceptLanguage)
+	first, _, _ := strings.Cut(header, ",")
+	tag, _, _ := strings.Cut(first, ";")
+	tag = strings.TrimSpace(tag)
+
+	if tag == "*" {
+		return ""
+	}
+
+	return tag
+}
+
+func parseProductFilter(ctx *fiber.Ctx) (S.ProductFilter, error) {
+	filter := S.ProductFilter{Name: ctx.Query("name"), Sort: ctx.Query("sort"), Seed: ctx.Query("seed")}
+
+	for key, target := range map[string]**decimal.Decimal{
+		"min_price": &filter.MinPrice,
+		"max_price": &filter.MaxPrice,
+	} {
+		if raw := ctx.Query(key); raw != "" {
+			value, err := decimal.NewFromString(raw)
+
+			if err != nil {
+				return filter, fmt.Errorf("invalid %s: %w", key, err)
+			}
+
+			*target = &value
+		}
+	}
+
+	for key, target := range map[string]*int{
+		"page":      &filter.Page,
+		"page_size": &filter.PageSize,
+	} {
+		if raw := ctx.Query(key); raw != "" {
+			value, err := strconv.Atoi(raw)
+
+			if err != nil {
+				return filter, fmt.Errorf("invalid %s: %w", key, err)
+			}
+
+			*target = value
+		}
+	}
+
+	return filter, nil
+}
@@ -11,9 +11,12 @@ import (
 func SetupProductsRoutes(router fiber.Router) {
 
 	router.Get("/products", mw.RateLimit(C.Tier3, 0), controllers.GetProducts)
+	router.Get("/products/list", mw.RateLimit(C.Tier3, 0), controllers.ListProducts)
+	router.Get("/products/compare", mw.RateLimit(C.Tier3, 0), controllers.CompareProducts)
 	router.Get("/products/:id", mw.RateLimit(C.Tier3, 0), controllers.GetProduct)
 
 	router.Post("/products", mw.RateLimit(C.Tier2, 0), controllers.CreateProduct)
+	router.Post("/products/bulk", mw.RateLimit(C.Tier2, 0), controllers.BulkCreateProducts)
 
 	router.Patch("/products/:id", mw.RateLimit(C.Tier2, 0), controllers.UpdateProduct)
 
@@ -0,0 +1,396 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/aarondl/sqlboiler/v4/types"
+	C "github.com/atharvbhadange/go-api-template/constants"
+	M "github.com/atharvbhadange/go-api-template/models"
+	T "github.com/atharvbhadange/go-api-template/types"
+	U "github.com/atharvbhadange/go-api-template/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+var auditActions = map[string]bool{
+	AuditActionCreate: true,
+	AuditActionUpdate: true,
+	AuditActionDelete: true,
+}
+
+// AuditEntry is one row of audit_log.
+type AuditEntry struct {
+	ID        int         `boil:"id" json:"id"`
+	Action    string      `boil:"action" json:"action"`
+	ProductID int         `boil:"product_id" json:"product_id"`
+	UserID    null.String `boil:"user_id" json:"user_id"`
+	Before    null.JSON   `boil:"before" json:"before"`
+	After     null.JSON   `boil:"after" json:"after"`
+	CreatedAt time.Time   `boil:"created_at" json:"created_at"`
+}
+
+// AuditLogger records who did what to which product. It is called inside the
+// write's transaction, and a returned error fails the write. before is nil for
+// creates and after is nil for deletes. The acting user is read from ctx with
+// U.UserIDFromContext.
+type AuditLogger interface {
+	Record(ctx context.Context, dbTrx boil.ContextExecutor, action string, productID int, before, after *M.Product) error
+}
+
+// AuditRecord is one entry for BatchAuditLogger.
+type AuditRecord struct {
+	Action    string
+	ProductID int
+	Before    *M.Product
+	After     *M.Product
+}
+
+// BatchAuditLogger is implemented by loggers that can store many entries at
+// once. Bulk writes use it when available and fall back to one Record per row.
+type BatchAuditLogger interface {
+	RecordMany(ctx context.Context, dbTrx boil.ContextExecutor, records []AuditRecord) error
+}
+
+// DBAuditLogger writes to the audit_log table through the caller's transaction,
+// so an entry exists exactly when the change it describes was committed.
+type DBAuditLogger struct{}
+
+func (DBAuditLogger) Record(ctx context.Context, dbTrx boil.ContextExecutor, action string, productID int, before, after *M.Product) error {
+	beforeJSON, err := auditSnapshot(before)
+	if err != nil {
+		return err
+	}
+
+	afterJSON, err := auditSnapshot(after)
+	if err != nil {
+		return err
+	}
+
+	userID, ok := U.UserIDFromContext(ctx)
+
+	_, err = queries.Raw(
+		"INSERT INTO audit_log (action, product_id, user_id, before, after) VALUES ($1, $2, $3, $4, $5)",
+		action, productID, null.NewString(userID, ok), beforeJSON, afterJSON,
+	).ExecContext(ctx, dbTrx)
+
+	return err
+}
+
+// RecordMany inserts the records with multi-row INSERTs of up to
+// C.INSERT_BATCH_SIZE rows each, all through dbTrx, so a large bulk write stays
+// under Postgres's bind parameter limit.
+func (DBAuditLogger) RecordMany(ctx context.Context, dbTrx boil.ContextExecutor, records []AuditRecord) error {
+	userID, ok := U.UserIDFromContext(ctx)
+	user := null.NewString(userID, ok)
+
+	for start := 0; start < len(records); start += C.INSERT_BATCH_SIZE {
+		end := min(start+C.INSERT_BATCH_SIZE, len(records))
+
+		if err := insertAuditBatch(ctx, dbTrx, user, records[start:end]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func insertAuditBatch(ctx context.Context, dbTrx boil.ContextExecutor, user null.String, records []AuditRecord) error {
+	placeholders := make([]string, 0, len(records))
+	args := make([]interface{}, 0, len(records)*5)
+
+	for i, record := range records {
+		beforeJSON, err := auditSnapshot(record.Before)
+		if err != nil {
+			return err
+		}
+
+		afterJSON, err := auditSnapshot(record.After)
+		if err != nil {
+			return err
+		}
+
+		placeholders = append(placeholders, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)", i*5+1, i*5+2, i*5+3, i*5+4, i*5+5))
+		args = append(args, record.Action, record.ProductID, user, beforeJSON, afterJSON)
+	}
+
+	_, err := queries.Raw(
+		"INSERT INTO audit_log (action, product_id, user_id, before, after) VALUES "+strings.Join(placeholders, ", "),
+		args...,
+	).ExecContext(ctx, dbTrx)
+
+	return err
+}
+
+func auditSnapshot(product *M.Product) (null.JSON, error) {
+	if product == nil {
+		return null.JSON{}, nil
+	}
+
+	data, err := json.Marshal(product)
+	if err != nil {
+		return null.JSON{}, err
+	}
+
+	return null.JSONFrom(data), nil
+}
+
+// GetProductChanges returns the audit_log entries for one action recorded in
+// [from, to), oldest first. It reads the table DBAuditLogger writes, so it only
+// sees changes when that logger is in use.
+func (s *ProductService) GetProductChanges(dbTrx boil.ContextExecutor, ctx context.Context, action string, from, to time.Time) ([]*AuditEntry, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if !auditActions[action] {
+		return nil, &T.ServiceError{
+			Message:     fmt.Sprintf("Invalid operation %q, expected create, update or delete", action),
+			MessageCode: T.MsgAuditActionInvalid,
+			Error:       errors.New("unknown audit action"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	if !from.Before(to) {
+		return nil, &T.ServiceError{
+			Message:     "from must be before to",
+			MessageCode: T.MsgTimeRangeInvalid,
+			Error:       errors.New("invalid time range"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	entries := []*AuditEntry{}
+
+	err := queries.Raw(
+		"SELECT id, action, product_id, user_id, before, after, created_at FROM audit_log WHERE action = $1 AND created_at >= $2 AND created_at < $3 ORDER BY created_at, id",
+		action, from, to,
+	).Bind(ctx, dbTrx, &entries)
+	if err != nil {
+		return nil, &T.ServiceError{
+			Message: "Unable to get product changes",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	return entries, nil
+}
+
+// UserChange is one change a user made to a product. Product is the row as the
+// change left it, or as it was before a delete.
+type UserChange struct {
+	ProductID int        `json:"product_id"`
+	Action    string     `json:"action"`
+	Product   *M.Product `json:"product"`
+	ChangedAt time.Time  `json:"changed_at"`
+}
+
+// GetProductsChangedByUserBetween returns every change userID made in
+// [from, to), oldest first. Like GetProductChanges it reads audit_log, and the
+// user is the one WithUserID put on the request context at write time.
+func (s *ProductService) GetProductsChangedByUserBetween(dbTrx boil.ContextExecutor, ctx context.Context, userID string, from, to time.Time) ([]*UserChange, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	userID, serviceErr := validateUserID(userID)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	if !from.Before(to) {
+		return nil, &T.ServiceError{
+			Message:     "from must be before to",
+			MessageCode: T.MsgTimeRangeInvalid,
+			Error:       errors.New("invalid time range"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	entries := []*AuditEntry{}
+
+	err := queries.Raw(
+		"SELECT id, action, product_id, user_id, before, after, created_at FROM audit_log WHERE user_id = $1 AND created_at >= $2 AND created_at < $3 ORDER BY created_at, id",
+		userID, from, to,
+	).Bind(ctx, dbTrx, &entries)
+	if err != nil {
+		return nil, &T.ServiceError{
+			Message: "Unable to get user changes",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	changes := make([]*UserChange, len(entries))
+	for i, entry := range entries {
+		snapshot := entry.After
+		if !snapshot.Valid {
+			snapshot = entry.Before
+		}
+
+		var product *M.Product
+		if snapshot.Valid {
+			product = &M.Product{}
+			if err := json.Unmarshal(snapshot.JSON, product); err != nil {
+				return nil, &T.ServiceError{
+					Message: "Unable to read audit entry",
+					Error:   err,
+					Code:    fiber.StatusInternalServerError,
+				}
+			}
+		}
+
+		changes[i] = &UserChange{
+			ProductID: entry.ProductID,
+			Action:    entry.Action,
+			Product:   product,
+			ChangedAt: entry.CreatedAt,
+		}
+	}
+
+	return changes, nil
+}
+
+// GetProductsUpdatedBy returns the products userID was the last to create or
+// update, by id. products has no updated_by column, so the last writer is read
+// from audit_log, where unauthenticated writes have a NULL user and match no
+// one. Deleted products are left out.
+func (s *ProductService) GetProductsUpdatedBy(dbTrx boil.ContextExecutor, ctx context.Context, userID string) ([]*M.Product, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	userID, serviceErr := validateUserID(userID)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	products := []*M.Product{}
+
+	err := queries.Raw(`
+		SELECT p.* FROM products p
+		WHERE p.id IN (SELECT product_id FROM audit_log WHERE user_id = $1)
+		AND (
+			SELECT a.user_id FROM audit_log a
+			WHERE a.product_id = p.id AND a.action IN ($2, $3)
+			ORDER BY a.created_at DESC, a.id DESC
+			LIMIT 1
+		) = $1
+		ORDER BY p.id`,
+		userID, AuditActionCreate, AuditActionUpdate,
+	).Bind(ctx, dbTrx, &products)
+	if err != nil {
+		return nil, &T.ServiceError{
+			Message: "Unable to get products updated by user",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	return products, nil
+}
+
+func validateUserID(userID string) (string, *T.ServiceError) {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return "", &T.ServiceError{
+			Message:     "User id is required",
+			MessageCode: T.MsgUserIDRequired,
+			Error:       errors.New("empty user id"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+	return userID, nil
+}
+
+// PriceChange is the most recent price change of a product.
+type PriceChange struct {
+	Product   *M.Product    `json:"product"`
+	OldPrice  types.Decimal `json:"old_price"`
+	NewPrice  types.Decimal `json:"new_price"`
+	ChangedAt time.Time     `json:"changed_at"`
+}
+
+type priceChangeRow struct {
+	ProductID int           `boil:"product_id"`
+	OldPrice  types.Decimal `boil:"old_price"`
+	NewPrice  types.Decimal `boil:"new_price"`
+	ChangedAt time.Time     `boil:"changed_at"`
+}
+
+// GetProductsWithPriceChangeSince returns the products whose price was changed
+// after since, each with its latest old and new price, most recent change first.
+// The price history is the audit_log update entries, compared numerically so a
+// rewrite from 19.9 to 19.90 is not a change. Deleted products are left out.
+func (s *ProductService) GetProductsWithPriceChangeSince(dbTrx boil.ContextExecutor, ctx context.Context, since time.Time) ([]*PriceChange, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	rows := []*priceChangeRow{}
+
+	err := queries.Raw(`
+		SELECT * FROM (
+			SELECT DISTINCT ON (product_id)
+				product_id,
+				(before->>'price')::numeric AS old_price,
+				(after->>'price')::numeric AS new_price,
+				created_at AS changed_at
+			FROM audit_log
+			WHERE action = $1 AND created_at > $2
+				AND (before->>'price')::numeric IS DISTINCT FROM (after->>'price')::numeric
+			ORDER BY product_id, created_at DESC, id DESC
+		) latest
+		ORDER BY changed_at DESC, product_id`,
+		AuditActionUpdate, since,
+	).Bind(ctx, dbTrx, &rows)
+	if err != nil {
+		return nil, &T.ServiceError{
+			Message: "Unable to get price changes",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	ids := make([]int, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ProductID
+	}
+
+	// GetProductsByIDs keeps the input order, which is already change time order.
+	products, serviceErr := s.GetProductsByIDs(dbTrx, ctx, ids)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	byID := make(map[int]*M.Product, len(products))
+	for _, product := range products {
+		byID[product.ID] = product
+	}
+
+	changes := make([]*PriceChange, 0, len(products))
+	for _, row := range rows {
+		product, ok := byID[row.ProductID]
+		if !ok {
+			continue
+		}
+		changes = append(changes, &PriceChange{
+			Product:   product,
+			OldPrice:  row.OldPrice,
+			NewPrice:  row.NewPrice,
+			ChangedAt: row.ChangedAt,
+		})
+	}
+
+	return changes, nil
+}
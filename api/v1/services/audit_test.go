@@ -0,0 +1,106 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	S "github.com/atharvbhadange/go-api-template/api/v1/services"
+	"github.com/atharvbhadange/go-api-template/api/v1/services/testutil"
+	C "github.com/atharvbhadange/go-api-template/constants"
+	T "github.com/atharvbhadange/go-api-template/types"
+)
+
+func TestDBAuditLoggerRecordManyBatches(t *testing.T) {
+	tests := []struct {
+		name        string
+		records     int
+		wantBatches int
+	}{
+		{name: "none", records: 0, wantBatches: 0},
+		{name: "one batch", records: C.INSERT_BATCH_SIZE, wantBatches: 1},
+		{name: "past the bind parameter limit", records: 14000, wantBatches: 14},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := testutil.NewFakeExecutor()
+			for i := 0; i < tt.wantBatches; i++ {
+				fake.Push(testutil.FakeResult{})
+			}
+
+			records := make([]S.AuditRecord, tt.records)
+			for i := range records {
+				records[i] = S.AuditRecord{Action: S.AuditActionDelete, ProductID: i + 1}
+			}
+
+			if err := (S.DBAuditLogger{}).RecordMany(context.Background(), fake, records); err != nil {
+				t.Fatalf("RecordMany() returned %v", err)
+			}
+
+			calls := fake.Calls()
+			if len(calls) != tt.wantBatches {
+				t.Fatalf("RecordMany() ran %d statements, want %d", len(calls), tt.wantBatches)
+			}
+
+			rows := 0
+			for _, call := range calls {
+				if !strings.HasPrefix(call.Query, "INSERT INTO audit_log") {
+					t.Fatalf("unexpected statement %q", call.Query)
+				}
+				if len(call.Args) > 65535 {
+					t.Fatalf("statement has %d parameters, over the Postgres limit", len(call.Args))
+				}
+				rows += len(call.Args) / 5
+			}
+			if rows != tt.records {
+				t.Fatalf("RecordMany() inserted %d rows, want %d", rows, tt.records)
+			}
+		})
+	}
+}
+
+func TestGetProductsUpdatedBy(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   string
+		wantUser string
+		wantErr  string
+	}{
+		{name: "user", userID: "admin-1", wantUser: "admin-1"},
+		{name: "trimmed", userID: "  admin-1 ", wantUser: "admin-1"},
+		{name: "empty", userID: "", wantErr: T.MsgUserIDRequired},
+		{name: "blank", userID: "   ", wantErr: T.MsgUserIDRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &S.ProductService{}
+
+			fake := testutil.NewFakeExecutor()
+			fake.Push(testutil.ProductsResult(3, 8))
+
+			products, serviceErr := service.GetProductsUpdatedBy(fake, context.Background(), tt.userID)
+
+			if tt.wantErr != "" {
+				if serviceErr == nil || serviceErr.MessageCode != tt.wantErr {
+					t.Fatalf("GetProductsUpdatedBy(%q) = %+v, want %s", tt.userID, serviceErr, tt.wantErr)
+				}
+				if calls := fake.Calls(); len(calls) != 0 {
+					t.Fatalf("GetProductsUpdatedBy(%q) ran %d statements, want none", tt.userID, len(calls))
+				}
+				return
+			}
+
+			if serviceErr != nil {
+				t.Fatalf("GetProductsUpdatedBy(%q) = %q: %v", tt.userID, serviceErr.Message, serviceErr.Error)
+			}
+			if len(products) != 2 || products[0].ID != 3 || products[1].ID != 8 {
+				t.Fatalf("GetProductsUpdatedBy(%q) returned %d products, want 3 and 8", tt.userID, len(products))
+			}
+			if args := fake.Calls()[0].Args; args[0] != tt.wantUser {
+				t.Fatalf("GetProductsUpdatedBy(%q) queried user %v, want %q", tt.userID, args[0], tt.wantUser)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	C "github.com/atharvbhadange/go-api-template/constants"
+	M "github.com/atharvbhadange/go-api-template/models"
+	T "github.com/atharvbhadange/go-api-template/types"
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+)
+
+const defaultCurrencyScale = 2
+
+// Minor unit digits per ISO 4217 code for currencies that do not use two.
+var currencyScales = map[string]int32{
+	"BHD": 3,
+	"BIF": 0,
+	"CLP": 0,
+	"DJF": 0,
+	"GNF": 0,
+	"IQD": 3,
+	"ISK": 0,
+	"JOD": 3,
+	"JPY": 0,
+	"KMF": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"LYD": 3,
+	"OMR": 3,
+	"PYG": 0,
+	"RWF": 0,
+	"TND": 3,
+	"UGX": 0,
+	"VND": 0,
+	"VUV": 0,
+	"XAF": 0,
+	"XOF": 0,
+	"XPF": 0,
+}
+
+// Active ISO 4217 codes.
+var iso4217Codes = map[string]bool{
+	"AED": true, "AFN": true, "ALL": true, "AMD": true, "ANG": true, "AOA": true,
+	"ARS": true, "AUD": true, "AWG": true, "AZN": true, "BAM": true, "BBD": true,
+	"BDT": true, "BGN": true, "BHD": true, "BIF": true, "BMD": true, "BND": true,
+	"BOB": true, "BRL": true, "BSD": true, "BTN": true, "BWP": true, "BYN": true,
+	"BZD": true, "CAD": true, "CDF": true, "CHF": true, "CLP": true, "CNY": true,
+	"COP": true, "CRC": true, "CUP": true, "CVE": true, "CZK": true, "DJF": true,
+	"DKK": true, "DOP": true, "DZD": true, "EGP": true, "ERN": true, "ETB": true,
+	"EUR": true, "FJD": true, "FKP": true, "GBP": true, "GEL": true, "GHS": true,
+	"GIP": true, "GMD": true, "GNF": true, "GTQ": true, "GYD": true, "HKD": true,
+	"HNL": true, "HTG": true, "HUF": true, "IDR": true, "ILS": true, "INR": true,
+	"IQD": true, "IRR": true, "ISK": true, "JMD": true, "JOD": true, "JPY": true,
+	"KES": true, "KGS": true, "KHR": true, "KMF": true, "KPW": true, "KRW": true,
+	"KWD": true, "KYD": true, "KZT": true, "LAK": true, "LBP": true, "LKR": true,
+	"LRD": true, "LSL": true, "LYD": true, "MAD": true, "MDL": true, "MGA": true,
+	"MKD": true, "MMK": true, "MNT": true, "MOP": true, "MRU": true, "MUR": true,
+	"MVR": true, "MWK": true, "MXN": true, "MYR": true, "MZN": true, "NAD": true,
+	"NGN": true, "NIO": true, "NOK": true, "NPR": true, "NZD": true, "OMR": true,
+	"PAB": true, "PEN": true, "PGK": true, "PHP": true, "PKR": true, "PLN": true,
+	"PYG": true, "QAR": true, "RON": true, "RSD": true, "RUB": true, "RWF": true,
+	"SAR": true, "SBD": true, "SCR": true, "SDG": true, "SEK": true, "SGD": true,
+	"SHP": true, "SLE": true, "SOS": true, "SRD": true, "SSP": true, "STN": true,
+	"SVC": true, "SYP": true, "SZL": true, "THB": true, "TJS": true, "TMT": true,
+	"TND": true, "TOP": true, "TRY": true, "TTD": true, "TWD": true, "TZS": true,
+	"UAH": true, "UGX": true, "USD": true, "UYU": true, "UZS": true, "VES": true,
+	"VND": true, "VUV": true, "WST": true, "XAF": true, "XCD": true, "XOF": true,
+	"XPF": true, "YER": true, "ZAR": true, "ZMW": true, "ZWL": true,
+}
+
+// Spellings clients send in place of the ISO code, keyed uppercase.
+var currencyAliases = map[string]string{
+	"US$": "USD",
+	"$":   "USD",
+	"€":   "EUR",
+	"£":   "GBP",
+	"¥":   "JPY",
+	"₹":   "INR",
+	"C$":  "CAD",
+	"CA$": "CAD",
+	"A$":  "AUD",
+	"AU$": "AUD",
+	"NZ$": "NZD",
+	"HK$": "HKD",
+	"S$":  "SGD",
+	"R$":  "BRL",
+	"RMB": "CNY",
+}
+
+// NormalizeCurrency uppercases raw, resolves common aliases such as "US$" and
+// rejects anything that is not an ISO 4217 code, so "usd", "Usd" and "US$" are
+// all stored as "USD".
+func NormalizeCurrency(raw string) (string, *T.ServiceError) {
+	currency := strings.ToUpper(strings.TrimSpace(raw))
+
+	if alias, ok := currencyAliases[currency]; ok {
+		currency = alias
+	}
+
+	if !iso4217Codes[currency] {
+		return "", &T.ServiceError{
+			Message:     fmt.Sprintf("Unknown currency %q", raw),
+			MessageCode: T.MsgCurrencyUnknown,
+			Error:       errors.New("invalid currency code"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	return currency, nil
+}
+
+func CurrencyScale(currency string) int32 {
+	if scale, ok := currencyScales[strings.ToUpper(currency)]; ok {
+		return scale
+	}
+	return defaultCurrencyScale
+}
+
+// ValidatePriceScale rejects prices with more decimal places than the currency
+// allows, e.g. 19.999 USD or 19.5 JPY. Trailing zeros are not counted.
+func ValidatePriceScale(price decimal.Decimal, currency string) *T.ServiceError {
+	scale := CurrencyScale(currency)
+
+	if !price.Equal(price.Truncate(scale)) {
+		return &T.ServiceError{
+			Message:     fmt.Sprintf("Price cannot have more than %d decimal places for %s", scale, strings.ToUpper(currency)),
+			MessageCode: T.MsgProductPriceScale,
+			Error:       errors.New("invalid price scale"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	return nil
+}
+
+// PriceInCents returns the product price as an exact integer count of minor units
+// (cents for USD, yen for JPY). It errors instead of rounding when the price has
+// more precision than the currency allows or does not fit in an int64.
+func PriceInCents(product *M.Product) (int64, error) {
+	if product.Price.Big == nil {
+		return 0, errors.New("price is not set")
+	}
+
+	price, err := decimal.NewFromString(product.Price.String())
+	if err != nil {
+		return 0, err
+	}
+
+	minor := price.Shift(CurrencyScale(C.DEFAULT_CURRENCY))
+
+	if !minor.IsInteger() {
+		return 0, fmt.Errorf("price %s has more precision than %s allows", price, C.DEFAULT_CURRENCY)
+	}
+
+	cents := minor.BigInt()
+	if !cents.IsInt64() {
+		return 0, fmt.Errorf("price %s is out of range", price)
+	}
+
+	return cents.Int64(), nil
+}
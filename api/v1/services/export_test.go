@@ -0,0 +1,9 @@
+package services
+
+// Unexported validation helpers, exposed to the services_test package. The
+// tests live there because testutil imports this package.
+var (
+	ParsePriceToken         = parsePriceToken
+	ValidateDescriptionText = validateDescriptionText
+	DBErrorStatus           = dbErrorStatus
+)
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	T "github.com/atharvbhadange/go-api-template/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	maintenanceMode       atomic.Bool
+	maintenanceRetryAfter atomic.Int64
+)
+
+// SetMaintenanceMode turns the write gate on or off at runtime. While it is on every
+// mutating service function fails with a 503 and reads keep working.
+func SetMaintenanceMode(enabled bool, retryAfter time.Duration) {
+	maintenanceRetryAfter.Store(int64(retryAfter))
+	maintenanceMode.Store(enabled)
+}
+
+func MaintenanceMode() bool {
+	return maintenanceMode.Load()
+}
+
+func MaintenanceRetryAfter() time.Duration {
+	return time.Duration(maintenanceRetryAfter.Load())
+}
+
+func checkMaintenance() *T.ServiceError {
+	if !maintenanceMode.Load() {
+		return nil
+	}
+
+	return &T.ServiceError{
+		Message:     fmt.Sprintf("Service is in maintenance mode, retry after %d seconds", int(MaintenanceRetryAfter().Seconds())),
+		MessageCode: T.MsgMaintenance,
+		Error:       errors.New("maintenance mode"),
+		Code:        fiber.StatusServiceUnavailable,
+		RetryAfter:  MaintenanceRetryAfter(),
+	}
+}
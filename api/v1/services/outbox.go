@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	C "github.com/atharvbhadange/go-api-template/constants"
+	T "github.com/atharvbhadange/go-api-template/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+// OutboxEvent is one row of the outbox table. Event is "product." followed by
+// the audit action, and Payload is the product as the write left it, or as it
+// was before a delete.
+type OutboxEvent struct {
+	ID          int       `boil:"id" json:"id"`
+	Event       string    `boil:"event" json:"event"`
+	ProductID   int       `boil:"product_id" json:"product_id"`
+	Payload     null.JSON `boil:"payload" json:"payload"`
+	CreatedAt   time.Time `boil:"created_at" json:"created_at"`
+	ProcessedAt null.Time `boil:"processed_at" json:"processed_at"`
+}
+
+// writeOutbox queues one event per record when Config.Outbox is on, with
+// multi-row INSERTs of up to C.INSERT_BATCH_SIZE rows each.
+func (s *ProductService) writeOutbox(ctx context.Context, dbTrx boil.ContextExecutor, records []AuditRecord) *T.ServiceError {
+	if !s.Config.Outbox {
+		return nil
+	}
+
+	for start := 0; start < len(records); start += C.INSERT_BATCH_SIZE {
+		end := min(start+C.INSERT_BATCH_SIZE, len(records))
+
+		if serviceErr := insertOutboxBatch(ctx, dbTrx, records[start:end]); serviceErr != nil {
+			return serviceErr
+		}
+	}
+
+	return nil
+}
+
+func insertOutboxBatch(ctx context.Context, dbTrx boil.ContextExecutor, records []AuditRecord) *T.ServiceError {
+	placeholders := make([]string, 0, len(records))
+	args := make([]interface{}, 0, len(records)*3)
+
+	for i, record := range records {
+		product := record.After
+		if product == nil {
+			product = record.Before
+		}
+
+		payload, err := auditSnapshot(product)
+		if err != nil {
+			return &T.ServiceError{
+				Message: "Unable to write outbox event",
+				Error:   err,
+				Code:    fiber.StatusInternalServerError,
+			}
+		}
+
+		placeholders = append(placeholders, fmt.Sprintf("($%d, $%d, $%d)", i*3+1, i*3+2, i*3+3))
+		args = append(args, "product."+record.Action, record.ProductID, payload)
+	}
+
+	_, err := queries.Raw(
+		"INSERT INTO outbox (event, product_id, payload) VALUES "+strings.Join(placeholders, ", "),
+		args...,
+	).ExecContext(ctx, dbTrx)
+	if err != nil {
+		return &T.ServiceError{
+			Message: "Unable to write outbox event",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	return nil
+}
+
+// PollOutbox claims up to limit unprocessed events, oldest first, and marks them
+// processed. Rows another worker has claimed are skipped rather than waited
+// for. Run it in a transaction and commit only after the events were published:
+// a rollback puts them back, so delivery is at least once and consumers must
+// tolerate duplicates.
+func (s *ProductService) PollOutbox(dbTrx boil.ContextExecutor, ctx context.Context, limit int) ([]*OutboxEvent, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if limit <= 0 || limit > C.OUTBOX_POLL_MAX {
+		return nil, &T.ServiceError{
+			Message:     fmt.Sprintf("Limit must be between 1 and %d", C.OUTBOX_POLL_MAX),
+			MessageCode: T.MsgPaginationInvalid,
+			Error:       errors.New("invalid limit"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	events := []*OutboxEvent{}
+
+	err := queries.Raw(`
+		UPDATE outbox SET processed_at = CURRENT_TIMESTAMP
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE processed_at IS NULL
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, event, product_id, payload, created_at, processed_at`,
+		limit,
+	).Bind(ctx, dbTrx, &events)
+	if err != nil {
+		return nil, &T.ServiceError{
+			Message: "Unable to poll outbox",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	// RETURNING has no defined order.
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+
+	return events, nil
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/aarondl/sqlboiler/v4/types"
+	T "github.com/atharvbhadange/go-api-template/types"
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+)
+
+// Price is an exact money amount. It decodes from JSON numbers without going
+// through float64 and converts to the model's types.Decimal without a string
+// round trip at every call site. The zero value is unset: a body without a price
+// or with a null one. Amount reads it as 0; IsSet tells it apart from an explicit 0.
+type Price struct {
+	types.Decimal
+}
+
+func PriceFromDecimal(d decimal.Decimal) Price {
+	var price Price
+	// A shopspring decimal always renders as a plain finite number, which Scan accepts.
+	_ = price.Scan(d.String())
+	return price
+}
+
+// PriceFromString parses a plain decimal such as "19.99". NaN and infinities are
+// rejected.
+func PriceFromString(s string) (Price, error) {
+	d, err := decimal.NewFromString(strings.TrimSpace(s))
+	if err != nil {
+		return Price{}, err
+	}
+	return PriceFromDecimal(d), nil
+}
+
+// PriceFromCents builds a price from an integer count of the currency's minor
+// units, e.g. 1999 USD cents is 19.99 and 1999 JPY is 1999.
+func PriceFromCents(cents int64, currency string) Price {
+	return PriceFromDecimal(decimal.New(cents, -CurrencyScale(currency)))
+}
+
+func (p Price) IsSet() bool {
+	return p.Big != nil
+}
+
+// Amount returns the price as a shopspring decimal for arithmetic and comparisons.
+func (p Price) Amount() decimal.Decimal {
+	if p.Big == nil {
+		return decimal.Zero
+	}
+
+	amount, err := decimal.NewFromString(p.String())
+	if err != nil {
+		return decimal.Zero
+	}
+	return amount
+}
+
+// Model returns a copy to assign to M.Product.Price, so the two never share a
+// *decimal.Big.
+func (p Price) Model() types.Decimal {
+	var d types.Decimal
+	_ = d.Scan(p.Amount().String())
+	return d
+}
+
+// Validate rejects negative prices and prices with more decimal places than
+// currency allows.
+func (p Price) Validate(currency string) *T.ServiceError {
+	amount := p.Amount()
+
+	if amount.IsNegative() {
+		return &T.ServiceError{
+			Message:     "Price cannot be negative",
+			MessageCode: T.MsgProductPriceNegative,
+			Error:       errors.New("invalid price"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	return ValidatePriceScale(amount, currency)
+}
+
+func (p Price) MarshalJSON() ([]byte, error) {
+	return []byte(p.Amount().String()), nil
+}
+
+// UnmarshalJSON reads the raw JSON number token, so 19.99 stays exactly 19.99.
+func (p *Price) UnmarshalJSON(data []byte) error {
+	if strings.TrimSpace(string(data)) == "null" {
+		*p = Price{}
+		return nil
+	}
+
+	amount, err := parsePriceToken(json.RawMessage(data))
+	if err != nil {
+		return err
+	}
+
+	*p = PriceFromDecimal(amount)
+	return nil
+}
@@ -3,173 +3,934 @@ package services
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"slices"
 	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
 	"github.com/aarondl/sqlboiler/v4/types"
+	C "github.com/atharvbhadange/go-api-template/constants"
 	M "github.com/atharvbhadange/go-api-template/models"
 	T "github.com/atharvbhadange/go-api-template/types"
 	"github.com/gofiber/fiber/v2"
 	"github.com/shopspring/decimal"
 )
 
+const (
+	// Longest JSON number accepted for a price, and the largest decimal exponent
+	// it may carry.
+	maxPriceTokenLength = 64
+	maxPriceExponent    = 64
+)
+
 type ProductBody struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
-	Price       int    `json:"price"`
+	Price       Price  `json:"price"`
 }
 
-func GetProducts(dbTrx boil.ContextExecutor, ctx context.Context) ([]*M.Product, *T.ServiceError) {
-	products, err := M.Products().All(ctx, dbTrx)
+// parsePriceToken parses a JSON number token exactly. Strings, booleans and other
+// non-numeric tokens are rejected; null is treated as zero.
+func parsePriceToken(raw json.RawMessage) (decimal.Decimal, error) {
+	token := strings.TrimSpace(string(raw))
+
+	if token == "null" {
+		return decimal.Zero, nil
+	}
+
+	if token == "" || (token[0] != '-' && (token[0] < '0' || token[0] > '9')) {
+		return decimal.Zero, fmt.Errorf("price must be a JSON number, got %s", token)
+	}
+
+	// Bound the input before parsing: an exponent such as 1e1000000 parses into a
+	// tiny struct but expands to a million digits the first time it is printed.
+	if len(token) > maxPriceTokenLength {
+		return decimal.Zero, fmt.Errorf("price must be at most %d characters", maxPriceTokenLength)
+	}
+
+	price, err := decimal.NewFromString(token)
 	if err != nil {
-		return nil, &T.ServiceError{
+		return decimal.Zero, err
+	}
+
+	if exp := price.Exponent(); exp > maxPriceExponent || exp < -maxPriceExponent {
+		return decimal.Zero, fmt.Errorf("price %s is out of range", token)
+	}
+
+	return price, nil
+}
+
+func (body *ProductBody) Validate() *T.ServiceError {
+	return body.validate(false)
+}
+
+func (body *ProductBody) validate(allowEmptyName bool) *T.ServiceError {
+	if serviceErr := validateProductName(body.Name, allowEmptyName); serviceErr != nil {
+		return serviceErr
+	}
+
+	if serviceErr := validateDescriptionText(body.Description); serviceErr != nil {
+		return serviceErr
+	}
+
+	return body.Price.Validate(C.DEFAULT_CURRENCY)
+}
+
+// validateProductName requires valid UTF-8 without control characters, so
+// names containing NUL, escape sequences or line breaks never reach listings,
+// and a length within PRODUCT_NAME_MAX_LENGTH characters.
+func validateProductName(name string, allowEmpty bool) *T.ServiceError {
+	if !allowEmpty && strings.TrimSpace(name) == "" {
+		return &T.ServiceError{
+			Message:     "Name is required",
+			MessageCode: T.MsgProductNameRequired,
+			Error:       errors.New("invalid name"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	if !utf8.ValidString(name) || strings.IndexFunc(name, unicode.IsControl) >= 0 {
+		return &T.ServiceError{
+			Message:     "Name cannot contain control characters or invalid UTF-8",
+			MessageCode: T.MsgProductNameInvalid,
+			Error:       errors.New("invalid name"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	if utf8.RuneCountInString(name) > C.PRODUCT_NAME_MAX_LENGTH {
+		return &T.ServiceError{
+			Message:     fmt.Sprintf("Name cannot be longer than %d characters", C.PRODUCT_NAME_MAX_LENGTH),
+			MessageCode: T.MsgProductNameTooLong,
+			Error:       errors.New("invalid name"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	return nil
+}
+
+// validateDescriptionText allows line breaks and tabs in descriptions but no
+// other control characters, and requires valid UTF-8, which Postgres rejects
+// with an opaque error otherwise.
+func validateDescriptionText(description string) *T.ServiceError {
+	invalid := strings.IndexFunc(description, func(r rune) bool {
+		return unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t'
+	})
+
+	if !utf8.ValidString(description) || invalid >= 0 {
+		return &T.ServiceError{
+			Message:     "Description cannot contain control characters or invalid UTF-8",
+			MessageCode: T.MsgProductDescriptionInvalid,
+			Error:       errors.New("invalid description"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	return nil
+}
+
+// checkBodyConfig applies the checks that depend on the service config rather
+// than on the body alone.
+func (s *ProductService) checkBodyConfig(body *ProductBody) *T.ServiceError {
+	if !body.Price.IsSet() && !s.Config.AllowMissingPrice {
+		return &T.ServiceError{
+			Message:     "Price is required, send 0 for a free product",
+			MessageCode: T.MsgProductPriceRequired,
+			Error:       errors.New("missing price"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	if body.Description == "" && s.Config.EmptyDescription == EmptyDescriptionReject {
+		return emptyDescriptionError()
+	}
+
+	return s.checkDescriptionLength(body.Description)
+}
+
+func emptyDescriptionError() *T.ServiceError {
+	return &T.ServiceError{
+		Message:     "Description is required",
+		MessageCode: T.MsgProductDescriptionRequired,
+		Error:       errors.New("empty description"),
+		Code:        fiber.StatusBadRequest,
+	}
+}
+
+// descriptionValue is the stored form of description under EmptyDescription.
+// Callers reject empty descriptions first when the policy requires one.
+func (s *ProductService) descriptionValue(description string) null.String {
+	return s.Config.EmptyDescription.store(description)
+}
+
+// store returns description as policy stores it. EmptyDescriptionReject
+// stores NULL, for callers that have already rejected empty descriptions.
+func (policy EmptyDescriptionPolicy) store(description string) null.String {
+	if description == "" && policy != EmptyDescriptionString {
+		return null.String{}
+	}
+	return null.StringFrom(description)
+}
+
+// applyBody is ApplyTo under the service's EmptyDescription policy.
+func (s *ProductService) applyBody(body *ProductBody, product *M.Product) {
+	body.ApplyTo(product, s.Config.EmptyDescription)
+}
+
+// checkDescriptionLength enforces MaxDescriptionLength, counted in runes so
+// multibyte text gets the same allowance as ASCII.
+func (s *ProductService) checkDescriptionLength(description string) *T.ServiceError {
+	limit := s.Config.MaxDescriptionLength
+
+	if limit > 0 && utf8.RuneCountInString(description) > limit {
+		return &T.ServiceError{
+			Message:     fmt.Sprintf("Description cannot be longer than %d characters", limit),
+			MessageCode: T.MsgProductDescriptionTooLong,
+			Error:       errors.New("invalid description"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	return nil
+}
+
+// ApplyTo copies the body onto product, which may be new or loaded, storing an
+// empty description as policy says. Call it after Validate; it does no checks
+// of its own.
+func (body *ProductBody) ApplyTo(product *M.Product, policy EmptyDescriptionPolicy) {
+	product.Name = body.Name
+	product.Description = policy.store(body.Description)
+	product.Price = body.Price.Model()
+}
+
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// DiffProducts returns the fields that differ between old and updated, keyed by column name.
+// A null description is reported as nil so it stays distinguishable from an empty string.
+func DiffProducts(old, updated *M.Product) map[string]FieldChange {
+	changes := map[string]FieldChange{}
+
+	if old.Name != updated.Name {
+		changes[M.ProductColumns.Name] = FieldChange{Before: old.Name, After: updated.Name}
+	}
+
+	if old.Description.Valid != updated.Description.Valid || old.Description.String != updated.Description.String {
+		changes[M.ProductColumns.Description] = FieldChange{
+			Before: nullStringValue(old.Description),
+			After:  nullStringValue(updated.Description),
+		}
+	}
+
+	if !decimalEqual(old.Price, updated.Price) {
+		changes[M.ProductColumns.Price] = FieldChange{Before: old.Price.String(), After: updated.Price.String()}
+	}
+
+	return changes
+}
+
+func nullStringValue(s null.String) interface{} {
+	if !s.Valid {
+		return nil
+	}
+	return s.String
+}
+
+func decimalEqual(a, b types.Decimal) bool {
+	if a.Big == nil || b.Big == nil {
+		return a.Big == b.Big
+	}
+	return a.Cmp(b.Big) == 0
+}
+
+func (s *ProductService) GetProducts(dbTrx boil.ContextExecutor, ctx context.Context) ([]*M.Product, ListMeta, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	mods := append([]qm.QueryMod{qm.OrderBy(M.ProductTableColumns.ID)}, s.softLimitMods()...)
+
+	products, err := M.Products(mods...).All(ctx, dbTrx)
+	if err != nil {
+		return nil, ListMeta{}, &T.ServiceError{
 			Message: "Unable to get products",
 			Error:   err,
-			Code:    fiber.StatusInternalServerError,
+			Code:    dbErrorStatus(err),
 		}
 	}
-	return products, nil
+
+	products, meta, err := applySoftLimit(s, dbTrx, ctx, products, nil)
+	if err != nil {
+		return nil, ListMeta{}, &T.ServiceError{
+			Message: "Unable to count products",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	return products, meta, nil
 }
 
-func GetProduct(dbTrx boil.ContextExecutor, ctx context.Context, id int) (*M.Product, *T.ServiceError) {
+// validateProductID rejects ids the serial primary key can never hold, so they
+// fail with 400 without a round trip instead of a 404 from the database.
+func validateProductID(id int) *T.ServiceError {
+	if id <= 0 {
+		return &T.ServiceError{
+			Message:     fmt.Sprintf("Invalid product id %d", id),
+			MessageCode: T.MsgProductIDInvalid,
+			Error:       errors.New("product id must be positive"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+	return nil
+}
+
+func (s *ProductService) GetProduct(dbTrx boil.ContextExecutor, ctx context.Context, id int) (*M.Product, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if serviceErr := validateProductID(id); serviceErr != nil {
+		return nil, serviceErr
+	}
+
 	product, err := M.FindProduct(ctx, dbTrx, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, &T.ServiceError{
-				Message: "Product not found",
-				Error:   err,
-				Code:    fiber.StatusNotFound,
+				Message:     "Product not found",
+				MessageCode: T.MsgProductNotFound,
+				Error:       err,
+				Code:        fiber.StatusNotFound,
 			}
 		}
 		return nil, &T.ServiceError{
 			Message: "Unable to get product",
 			Error:   err,
-			Code:    fiber.StatusInternalServerError,
+			Code:    dbErrorStatus(err),
 		}
 	}
 	return product, nil
 }
 
-func CreateProduct(dbTrx boil.ContextExecutor, ctx context.Context, body *ProductBody) (*M.Product, *T.ServiceError) {
-	if body.Price < 0 {
+// GetProductsByIDs returns the products for ids in the order they were first requested.
+// Duplicate ids are collapsed and unknown ids are skipped. Large id lists are queried
+// in chunks of Config.IDsChunkSize to stay under driver parameter limits.
+func (s *ProductService) GetProductsByIDs(dbTrx boil.ContextExecutor, ctx context.Context, ids []int) ([]*M.Product, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	uniqueIDs := uniqueProductIDs(ids)
+
+	chunkSize := s.idsChunkSize()
+
+	byID := make(map[int]*M.Product, len(uniqueIDs))
+
+	for start := 0; start < len(uniqueIDs); start += chunkSize {
+		end := min(start+chunkSize, len(uniqueIDs))
+
+		products, err := M.Products(M.ProductWhere.ID.IN(uniqueIDs[start:end])).All(ctx, dbTrx)
+		if err != nil {
+			return nil, &T.ServiceError{
+				Message: "Unable to get products",
+				Error:   err,
+				Code:    dbErrorStatus(err),
+			}
+		}
+
+		for _, product := range products {
+			byID[product.ID] = product
+		}
+	}
+
+	products := make([]*M.Product, 0, len(byID))
+	for _, id := range uniqueIDs {
+		if product, ok := byID[id]; ok {
+			products = append(products, product)
+		}
+	}
+
+	return products, nil
+}
+
+// GetProductsByIDsSorted returns the given products ordered by sort, which
+// accepts the same columns as the list endpoints. The id lookup and the ORDER BY
+// run in one statement: the primary key finds the rows and only that small set
+// is sorted. For that reason at most IDsChunkSize ids are accepted.
+func (s *ProductService) GetProductsByIDsSorted(dbTrx boil.ContextExecutor, ctx context.Context, ids []int, sort string) ([]*M.Product, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	orderBy, serviceErr := productOrderBy(sort, M.ProductColumns.ID)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	uniqueIDs := uniqueProductIDs(ids)
+
+	if len(uniqueIDs) > s.idsChunkSize() {
 		return nil, &T.ServiceError{
-			Message: "Price cannot be negative",
-			Error:   errors.New("invalid price"),
-			Code:    fiber.StatusBadRequest,
+			Message:     fmt.Sprintf("Cannot sort more than %d ids", s.idsChunkSize()),
+			MessageCode: T.MsgTooManyIDs,
+			Error:       errors.New("too many ids"),
+			Code:        fiber.StatusBadRequest,
 		}
 	}
 
-	// Convert int to decimal.Decimal, then to types.Decimal via string
-	dec, err := decimal.NewFromString(strconv.Itoa(body.Price))
+	if len(uniqueIDs) == 0 {
+		return []*M.Product{}, nil
+	}
+
+	products, err := M.Products(M.ProductWhere.ID.IN(uniqueIDs), orderBy).All(ctx, dbTrx)
 	if err != nil {
 		return nil, &T.ServiceError{
-			Message: "Invalid price format",
+			Message: "Unable to get products",
 			Error:   err,
-			Code:    fiber.StatusBadRequest,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	return products, nil
+}
+
+// GetProductsByIDsStrict is GetProductsByIDs for callers that need every id: if
+// any requested product does not exist it returns a 404 naming the missing ids
+// instead of a partial result.
+func (s *ProductService) GetProductsByIDsStrict(dbTrx boil.ContextExecutor, ctx context.Context, ids []int) ([]*M.Product, *T.ServiceError) {
+	products, serviceErr := s.GetProductsByIDs(dbTrx, ctx, ids)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	found := make(map[int]bool, len(products))
+	for _, product := range products {
+		found[product.ID] = true
+	}
+
+	missing := []string{}
+	for _, id := range uniqueProductIDs(ids) {
+		if !found[id] {
+			missing = append(missing, strconv.Itoa(id))
 		}
 	}
 
-	var price types.Decimal
-	if err := price.Scan(dec.String()); err != nil {
+	if len(missing) > 0 {
 		return nil, &T.ServiceError{
-			Message: "Failed to convert price to decimal",
+			Message:     "Products not found: " + strings.Join(missing, ", "),
+			MessageCode: T.MsgProductNotFound,
+			Error:       sql.ErrNoRows,
+			Code:        fiber.StatusNotFound,
+		}
+	}
+
+	return products, nil
+}
+
+func uniqueProductIDs(ids []int) []int {
+	unique := make([]int, 0, len(ids))
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// GetProductsByIDsForUpdate loads and row-locks the given products with SELECT ...
+// FOR UPDATE, returning them in ascending id order. It must run inside a
+// transaction: the locks are held until that transaction ends, and outside one
+// they are released as soon as the statement finishes. Rows are always locked in
+// id order, chunks included, so two batches touching overlapping products queue
+// behind each other instead of deadlocking. Missing ids are left out.
+func (s *ProductService) GetProductsByIDsForUpdate(dbTrx boil.ContextExecutor, ctx context.Context, ids []int) ([]*M.Product, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	uniqueIDs := uniqueProductIDs(ids)
+	slices.Sort(uniqueIDs)
+
+	chunkSize := s.idsChunkSize()
+
+	locked := make([]*M.Product, 0, len(uniqueIDs))
+
+	for start := 0; start < len(uniqueIDs); start += chunkSize {
+		end := min(start+chunkSize, len(uniqueIDs))
+
+		products, err := M.Products(
+			M.ProductWhere.ID.IN(uniqueIDs[start:end]),
+			qm.OrderBy(M.ProductColumns.ID),
+			qm.For("UPDATE"),
+		).All(ctx, dbTrx)
+		if err != nil {
+			return nil, &T.ServiceError{
+				Message: "Unable to lock products",
+				Error:   err,
+				Code:    dbErrorStatus(err),
+			}
+		}
+
+		locked = append(locked, products...)
+	}
+
+	return locked, nil
+}
+
+// GetProductsByExactPrice matches on numeric equality, so 19.9 and 19.90 find the same rows.
+func (s *ProductService) GetProductsByExactPrice(dbTrx boil.ContextExecutor, ctx context.Context, price types.Decimal) ([]*M.Product, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if price.Big == nil || !price.IsFinite() {
+		return nil, &T.ServiceError{
+			Message:     "Invalid price",
+			MessageCode: T.MsgProductPriceInvalid,
+			Error:       errors.New("price must be a finite number"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	if price.Sign() < 0 {
+		return nil, &T.ServiceError{
+			Message:     "Price cannot be negative",
+			MessageCode: T.MsgProductPriceNegative,
+			Error:       errors.New("invalid price"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	products, err := M.Products(
+		M.ProductWhere.Price.EQ(price),
+		qm.OrderBy(M.ProductColumns.ID),
+	).All(ctx, dbTrx)
+	if err != nil {
+		return nil, &T.ServiceError{
+			Message: "Unable to get products",
 			Error:   err,
-			Code:    fiber.StatusInternalServerError,
+			Code:    dbErrorStatus(err),
 		}
 	}
 
-	product := M.Product{
-		Name:        body.Name,
-		Description: null.String{String: body.Description, Valid: body.Description != ""},
-		Price:       price,
+	if products == nil {
+		products = M.ProductSlice{}
+	}
+
+	return products, nil
+}
+
+func (s *ProductService) ProductsExist(dbTrx boil.ContextExecutor, ctx context.Context, ids []int) ([]int, []int, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	existing := []int{}
+	missing := []int{}
+
+	uniqueIDs := uniqueProductIDs(ids)
+
+	chunkSize := s.idsChunkSize()
+
+	found := make(map[int]bool, len(uniqueIDs))
+
+	for start := 0; start < len(uniqueIDs); start += chunkSize {
+		end := min(start+chunkSize, len(uniqueIDs))
+
+		products, err := M.Products(
+			qm.Select(M.ProductColumns.ID),
+			M.ProductWhere.ID.IN(uniqueIDs[start:end]),
+		).All(ctx, dbTrx)
+		if err != nil {
+			return nil, nil, &T.ServiceError{
+				Message: "Unable to check products",
+				Error:   err,
+				Code:    dbErrorStatus(err),
+			}
+		}
+
+		for _, product := range products {
+			found[product.ID] = true
+		}
+	}
+
+	for _, id := range uniqueIDs {
+		if found[id] {
+			existing = append(existing, id)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	return existing, missing, nil
+}
+
+func (s *ProductService) CreateProduct(dbTrx boil.ContextExecutor, ctx context.Context, body *ProductBody) (*M.Product, *T.ServiceError) {
+	product, _, serviceErr := s.CreateOrGetProduct(dbTrx, ctx, body)
+	return product, serviceErr
+}
+
+// CreateOrGetProduct is CreateProduct that also reports whether it inserted the
+// product. created is false only under ReturnExistingOnConflict, when the
+// returned product is the row a concurrent create inserted first, so created
+// hooks must not run for it.
+func (s *ProductService) CreateOrGetProduct(dbTrx boil.ContextExecutor, ctx context.Context, body *ProductBody) (*M.Product, bool, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if serviceErr := checkMaintenance(); serviceErr != nil {
+		return nil, false, serviceErr
+	}
+
+	product, serviceErr := s.productFromBody(ctx, body)
+	if serviceErr != nil {
+		return nil, false, serviceErr
+	}
+
+	if s.Config.ReturnExistingOnConflict {
+		return s.insertOrGetExisting(dbTrx, ctx, product)
 	}
 
+	if serviceErr := s.insertProduct(dbTrx, ctx, product); serviceErr != nil {
+		return nil, false, serviceErr
+	}
+
+	return product, true, nil
+}
+
+// productFromBody normalizes and validates body and returns the product it
+// describes, not yet inserted.
+func (s *ProductService) productFromBody(ctx context.Context, body *ProductBody) (*M.Product, *T.ServiceError) {
+	body = s.prepareBody(body)
+
+	if serviceErr := body.Validate(); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	if serviceErr := s.checkBodyConfig(body); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	if serviceErr := s.runValidators(ctx, body); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	product := &M.Product{}
+	s.applyBody(body, product)
+
+	return product, nil
+}
+
+func (s *ProductService) insertProduct(dbTrx boil.ContextExecutor, ctx context.Context, product *M.Product) *T.ServiceError {
 	if err := product.Insert(ctx, dbTrx, boil.Infer()); err != nil {
-		return nil, &T.ServiceError{
+		// Deployments that put a unique index on name get a conflict instead of a
+		// 500 when two creates of the same name race.
+		if isUniqueViolation(err) {
+			return &T.ServiceError{
+				Message:     "A product with this name already exists",
+				MessageCode: T.MsgProductNameConflict,
+				Error:       err,
+				Code:        fiber.StatusConflict,
+			}
+		}
+		return &T.ServiceError{
 			Message: "Unable to create product",
 			Error:   err,
-			Code:    fiber.StatusInternalServerError,
+			Code:    dbErrorStatus(err),
 		}
 	}
 
-	return &product, nil
+	return s.audit(ctx, dbTrx, AuditActionCreate, product.ID, nil, product)
 }
 
-func UpdateProduct(dbTrx boil.ContextExecutor, ctx context.Context, id int, body *ProductBody) (*M.Product, *T.ServiceError) {
+// UpdateProduct replaces the product's fields with body. Besides the updated
+// product and its changes it returns the price watches the new price met, to be
+// delivered once the transaction has committed.
+func (s *ProductService) UpdateProduct(dbTrx boil.ContextExecutor, ctx context.Context, id int, body *ProductBody, force bool) (*M.Product, map[string]FieldChange, []*PriceWatch, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if serviceErr := validateProductID(id); serviceErr != nil {
+		return nil, nil, nil, serviceErr
+	}
+
+	if serviceErr := checkMaintenance(); serviceErr != nil {
+		return nil, nil, nil, serviceErr
+	}
+
 	product, err := M.FindProduct(ctx, dbTrx, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, &T.ServiceError{
-				Message: "Product not found",
-				Error:   err,
-				Code:    fiber.StatusNotFound,
+			return nil, nil, nil, &T.ServiceError{
+				Message:     "Product not found",
+				MessageCode: T.MsgProductNotFound,
+				Error:       err,
+				Code:        fiber.StatusNotFound,
 			}
 		}
-		return nil, &T.ServiceError{
+		return nil, nil, nil, &T.ServiceError{
 			Message: "Unable to get product",
 			Error:   err,
-			Code:    fiber.StatusInternalServerError,
+			Code:    dbErrorStatus(err),
 		}
 	}
 
-	if body.Price < 0 {
-		return nil, &T.ServiceError{
-			Message: "Price cannot be negative",
-			Error:   errors.New("invalid price"),
-			Code:    fiber.StatusBadRequest,
+	body = s.prepareBody(body)
+
+	allowEmptyName := false
+	if strings.TrimSpace(body.Name) == "" {
+		switch s.Config.EmptyNameOnUpdate {
+		case EmptyNameKeep:
+			body.Name = product.Name
+		case EmptyNameAllow:
+			allowEmptyName = true
 		}
 	}
 
-	// Convert int to decimal.Decimal, then to types.Decimal via string
-	dec, err := decimal.NewFromString(strconv.Itoa(body.Price))
-	if err != nil {
-		return nil, &T.ServiceError{
-			Message: "Invalid price format",
+	if serviceErr := body.validate(allowEmptyName); serviceErr != nil {
+		return nil, nil, nil, serviceErr
+	}
+
+	if serviceErr := s.checkBodyConfig(body); serviceErr != nil {
+		return nil, nil, nil, serviceErr
+	}
+
+	if serviceErr := s.runValidators(ctx, body); serviceErr != nil {
+		return nil, nil, nil, serviceErr
+	}
+
+	if serviceErr := s.checkPriceChange(product.Price, body.Price.Amount(), force); serviceErr != nil {
+		return nil, nil, nil, serviceErr
+	}
+
+	before := *product
+
+	s.applyBody(body, product)
+
+	if _, err := product.Update(ctx, dbTrx, boil.Infer()); err != nil {
+		return nil, nil, nil, &T.ServiceError{
+			Message: "Unable to update product",
 			Error:   err,
-			Code:    fiber.StatusBadRequest,
+			Code:    dbErrorStatus(err),
 		}
 	}
 
-	var price types.Decimal
-	if err := price.Scan(dec.String()); err != nil {
-		return nil, &T.ServiceError{
-			Message: "Failed to convert price to decimal",
+	if serviceErr := s.audit(ctx, dbTrx, AuditActionUpdate, product.ID, &before, product); serviceErr != nil {
+		return nil, nil, nil, serviceErr
+	}
+
+	watches, serviceErr := s.checkPriceWatchesOnChange(dbTrx, ctx, product.ID, before.Price, product.Price)
+	if serviceErr != nil {
+		return nil, nil, nil, serviceErr
+	}
+
+	s.invalidateCache(ctx, product.ID)
+
+	return product, DiffProducts(&before, product), watches, nil
+}
+
+// MergePatchProduct applies a JSON Merge Patch (RFC 7386) document to a product.
+// Absent members are left unchanged and a null member clears the field, which is
+// only allowed for nullable columns. A price change goes through the same guard
+// as UpdateProduct, skipped with force, and the changes and price watches are
+// returned as from UpdateProduct.
+func (s *ProductService) MergePatchProduct(dbTrx boil.ContextExecutor, ctx context.Context, id int, raw json.RawMessage, force bool) (*M.Product, map[string]FieldChange, []*PriceWatch, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if serviceErr := validateProductID(id); serviceErr != nil {
+		return nil, nil, nil, serviceErr
+	}
+
+	if serviceErr := checkMaintenance(); serviceErr != nil {
+		return nil, nil, nil, serviceErr
+	}
+
+	patch := map[string]json.RawMessage{}
+
+	if err := json.Unmarshal(raw, &patch); err != nil {
+		return nil, nil, nil, &T.ServiceError{
+			Message:     "Invalid merge patch document",
+			MessageCode: T.MsgDocumentInvalid,
+			Error:       err,
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	product, err := M.FindProduct(ctx, dbTrx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, nil, &T.ServiceError{
+				Message:     "Product not found",
+				MessageCode: T.MsgProductNotFound,
+				Error:       err,
+				Code:        fiber.StatusNotFound,
+			}
+		}
+		return nil, nil, nil, &T.ServiceError{
+			Message: "Unable to get product",
 			Error:   err,
-			Code:    fiber.StatusInternalServerError,
+			Code:    dbErrorStatus(err),
 		}
 	}
 
-	product.Name = body.Name
-	product.Description = null.String{String: body.Description, Valid: body.Description != ""}
-	product.Price = price
+	before := *product
+
+	for key, value := range patch {
+		isNull := string(value) == "null"
+
+		switch key {
+		case M.ProductColumns.Name:
+			if isNull {
+				return nil, nil, nil, &T.ServiceError{
+					Message:     "Name cannot be removed",
+					MessageCode: T.MsgProductNameRequired,
+					Error:       errors.New("name is not nullable"),
+					Code:        fiber.StatusBadRequest,
+				}
+			}
+			var name string
+			if err := json.Unmarshal(value, &name); err != nil {
+				return nil, nil, nil, &T.ServiceError{
+					Message:     "Invalid name",
+					MessageCode: T.MsgProductNameInvalid,
+					Error:       err,
+					Code:        fiber.StatusBadRequest,
+				}
+			}
+			name = s.preparePatchedName(name)
+			if strings.TrimSpace(name) == "" && s.Config.EmptyNameOnUpdate == EmptyNameKeep {
+				continue
+			}
+			if serviceErr := validateProductName(name, s.Config.EmptyNameOnUpdate == EmptyNameAllow); serviceErr != nil {
+				return nil, nil, nil, serviceErr
+			}
+			product.Name = name
+		case M.ProductColumns.Description:
+			// null and "" mean the same here, both stored under EmptyDescription.
+			var description string
+			if !isNull {
+				if err := json.Unmarshal(value, &description); err != nil {
+					return nil, nil, nil, &T.ServiceError{
+						Message:     "Invalid description",
+						MessageCode: T.MsgProductDescriptionInvalid,
+						Error:       err,
+						Code:        fiber.StatusBadRequest,
+					}
+				}
+			}
+			description = s.trimPatchedString(description)
+			if serviceErr := validateDescriptionText(description); serviceErr != nil {
+				return nil, nil, nil, serviceErr
+			}
+			if serviceErr := s.checkDescriptionLength(description); serviceErr != nil {
+				return nil, nil, nil, serviceErr
+			}
+			if description == "" && s.Config.EmptyDescription == EmptyDescriptionReject {
+				return nil, nil, nil, emptyDescriptionError()
+			}
+			product.Description = s.descriptionValue(description)
+		case M.ProductColumns.Price:
+			if isNull {
+				return nil, nil, nil, &T.ServiceError{
+					Message:     "Price cannot be removed",
+					MessageCode: T.MsgProductPriceRequired,
+					Error:       errors.New("price is not nullable"),
+					Code:        fiber.StatusBadRequest,
+				}
+			}
+			var price Price
+			if err := price.UnmarshalJSON(value); err != nil {
+				return nil, nil, nil, &T.ServiceError{
+					Message:     "Invalid price format",
+					MessageCode: T.MsgProductPriceInvalid,
+					Error:       err,
+					Code:        fiber.StatusBadRequest,
+				}
+			}
+			if serviceErr := price.Validate(C.DEFAULT_CURRENCY); serviceErr != nil {
+				return nil, nil, nil, serviceErr
+			}
+			if serviceErr := s.checkPriceChange(before.Price, price.Amount(), force); serviceErr != nil {
+				return nil, nil, nil, serviceErr
+			}
+			product.Price = price.Model()
+		default:
+			return nil, nil, nil, &T.ServiceError{
+				Message:     "Unknown field " + key,
+				MessageCode: T.MsgFieldUnknown,
+				Error:       errors.New("unknown merge patch member"),
+				Code:        fiber.StatusBadRequest,
+			}
+		}
+	}
+
+	// Deployment rules see the product as the patch leaves it, the same shape
+	// UpdateProduct hands them.
+	patched := &ProductBody{
+		Name:        product.Name,
+		Description: product.Description.String,
+		Price:       Price{product.Price},
+	}
+
+	if serviceErr := s.runValidators(ctx, patched); serviceErr != nil {
+		return nil, nil, nil, serviceErr
+	}
 
 	if _, err := product.Update(ctx, dbTrx, boil.Infer()); err != nil {
-		return nil, &T.ServiceError{
+		return nil, nil, nil, &T.ServiceError{
 			Message: "Unable to update product",
 			Error:   err,
-			Code:    fiber.StatusInternalServerError,
+			Code:    dbErrorStatus(err),
 		}
 	}
 
-	return product, nil
+	if serviceErr := s.audit(ctx, dbTrx, AuditActionUpdate, product.ID, &before, product); serviceErr != nil {
+		return nil, nil, nil, serviceErr
+	}
+
+	watches, serviceErr := s.checkPriceWatchesOnChange(dbTrx, ctx, product.ID, before.Price, product.Price)
+	if serviceErr != nil {
+		return nil, nil, nil, serviceErr
+	}
+
+	s.invalidateCache(ctx, product.ID)
+
+	return product, DiffProducts(&before, product), watches, nil
 }
 
-func DeleteProduct(dbTrx boil.ContextExecutor, ctx context.Context, id int) *T.ServiceError {
+func (s *ProductService) DeleteProduct(dbTrx boil.ContextExecutor, ctx context.Context, id int) *T.ServiceError {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if serviceErr := validateProductID(id); serviceErr != nil {
+		return serviceErr
+	}
+
+	if serviceErr := checkMaintenance(); serviceErr != nil {
+		return serviceErr
+	}
+
 	product, err := M.FindProduct(ctx, dbTrx, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return &T.ServiceError{
-				Message: "Product not found",
-				Error:   err,
-				Code:    fiber.StatusNotFound,
+				Message:     "Product not found",
+				MessageCode: T.MsgProductNotFound,
+				Error:       err,
+				Code:        fiber.StatusNotFound,
 			}
 		}
 		return &T.ServiceError{
 			Message: "Unable to get product",
 			Error:   err,
-			Code:    fiber.StatusInternalServerError,
+			Code:    dbErrorStatus(err),
 		}
 	}
 
@@ -177,9 +938,58 @@ func DeleteProduct(dbTrx boil.ContextExecutor, ctx context.Context, id int) *T.S
 		return &T.ServiceError{
 			Message: "Unable to delete product",
 			Error:   err,
-			Code:    fiber.StatusInternalServerError,
+			Code:    dbErrorStatus(err),
 		}
 	}
 
+	if serviceErr := s.audit(ctx, dbTrx, AuditActionDelete, product.ID, product, nil); serviceErr != nil {
+		return serviceErr
+	}
+
+	s.invalidateCache(ctx, product.ID)
+
 	return nil
 }
+
+// DeleteProductIfExists deletes the product and also succeeds when it is already
+// gone, so retried deletes are idempotent. It reports whether a row was removed.
+func (s *ProductService) DeleteProductIfExists(dbTrx boil.ContextExecutor, ctx context.Context, id int) (bool, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if serviceErr := validateProductID(id); serviceErr != nil {
+		return false, serviceErr
+	}
+
+	if serviceErr := checkMaintenance(); serviceErr != nil {
+		return false, serviceErr
+	}
+
+	product, err := M.FindProduct(ctx, dbTrx, id)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, &T.ServiceError{
+			Message: "Unable to get product",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	if _, err := product.Delete(ctx, dbTrx); err != nil {
+		return false, &T.ServiceError{
+			Message: "Unable to delete product",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	if serviceErr := s.audit(ctx, dbTrx, AuditActionDelete, product.ID, product, nil); serviceErr != nil {
+		return false, serviceErr
+	}
+
+	s.invalidateCache(ctx, product.ID)
+
+	return true, nil
+}
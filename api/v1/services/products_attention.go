@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	M "github.com/atharvbhadange/go-api-template/models"
+	T "github.com/atharvbhadange/go-api-template/types"
+)
+
+type ProductAttention struct {
+	Product *M.Product `json:"product"`
+	Reasons []string   `json:"reasons"`
+}
+
+// Each check pairs the SQL condition used to find candidates with the Go
+// predicate used to explain why a returned row matched. Image, stock and
+// category checks belong here once those columns exist.
+var attentionChecks = []struct {
+	reason  string
+	clause  string
+	matches func(product *M.Product) bool
+}{
+	{
+		reason: "empty_description",
+		clause: "(" + M.ProductTableColumns.Description + " IS NULL OR trim(" + M.ProductTableColumns.Description + ") = '')",
+		matches: func(product *M.Product) bool {
+			return !product.Description.Valid || strings.TrimSpace(product.Description.String) == ""
+		},
+	},
+}
+
+func (s *ProductService) GetProductsNeedingAttention(dbTrx boil.ContextExecutor, ctx context.Context) ([]*ProductAttention, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	clauses := make([]string, 0, len(attentionChecks))
+	for _, check := range attentionChecks {
+		clauses = append(clauses, check.clause)
+	}
+
+	products, err := M.Products(
+		qm.Where(strings.Join(clauses, " OR ")),
+		qm.OrderBy(M.ProductColumns.ID),
+	).All(ctx, dbTrx)
+	if err != nil {
+		return nil, &T.ServiceError{
+			Message: "Unable to get products needing attention",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	results := make([]*ProductAttention, 0, len(products))
+	for _, product := range products {
+		result := &ProductAttention{Product: product, Reasons: []string{}}
+		for _, check := range attentionChecks {
+			if check.matches(product) {
+				result.Reasons = append(result.Reasons, check.reason)
+			}
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	C "github.com/atharvbhadange/go-api-template/constants"
+	M "github.com/atharvbhadange/go-api-template/models"
+	T "github.com/atharvbhadange/go-api-template/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ItemResult reports the outcome of one element of a bulk request. Exactly one
+// of Product and Error is set.
+type ItemResult struct {
+	Index   int        `json:"index"`
+	Product *M.Product `json:"product,omitempty"`
+	Error   *ItemError `json:"error,omitempty"`
+}
+
+type ItemError struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+// BulkCreateProducts inserts bodies in the caller's transaction and returns one
+// result per body, in input order. Validation runs for every body before
+// anything is inserted. In strict mode any invalid body fails the whole call
+// with 400 and nothing is inserted; the results still say which items were
+// rejected. In partial mode invalid bodies are skipped and the valid ones are
+// inserted. Database errors fail the call in both modes, since they leave the
+// transaction unusable.
+func (s *ProductService) BulkCreateProducts(dbTrx boil.ContextExecutor, ctx context.Context, bodies []*ProductBody, partial bool) ([]ItemResult, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if serviceErr := checkMaintenance(); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	results := make([]ItemResult, len(bodies))
+	products := make([]*M.Product, len(bodies))
+	invalid := 0
+
+	for i, body := range bodies {
+		results[i].Index = i
+
+		if body == nil {
+			body = &ProductBody{}
+		}
+
+		product, serviceErr := s.productFromBody(ctx, body)
+		if serviceErr != nil {
+			results[i].Error = &ItemError{Message: serviceErr.Message, Code: serviceErr.Code}
+			invalid++
+			continue
+		}
+
+		products[i] = product
+	}
+
+	if invalid > 0 && !partial {
+		return results, &T.ServiceError{
+			Message:     fmt.Sprintf("%d of %d products are invalid", invalid, len(bodies)),
+			MessageCode: T.MsgBulkItemsInvalid,
+			Error:       fmt.Errorf("bulk create rejected: %d invalid items", invalid),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	for i, product := range products {
+		if product == nil {
+			continue
+		}
+
+		if serviceErr := s.insertProduct(dbTrx, ctx, product); serviceErr != nil {
+			return nil, serviceErr
+		}
+
+		results[i].Product = product
+	}
+
+	return results, nil
+}
+
+// ProductUpdate changes some fields of one product. nil fields are left as they
+// are; an empty Description clears it.
+type ProductUpdate struct {
+	ID          int     `json:"id"`
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	Price       *Price  `json:"price"`
+}
+
+// BulkUpdateProducts applies updates in the caller's transaction with one SELECT
+// ... FOR UPDATE and one UPDATE ... FROM (VALUES ...) per IMPORT_BATCH_SIZE rows,
+// instead of a round trip per product. Every update is merged with the locked
+// row and validated like UpdateProduct before anything is written; one invalid
+// update fails the call. Ids that do not exist are returned in missing. The
+// price change guard does not apply: bulk updates come from sync jobs that are
+// the source of truth and are treated as forced. Price watches are not checked
+// either; a sync that should notify watchers calls CheckPriceWatches for the
+// products whose price it changed.
+func (s *ProductService) BulkUpdateProducts(dbTrx boil.ContextExecutor, ctx context.Context, updates []ProductUpdate) ([]*M.Product, []int, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if serviceErr := checkMaintenance(); serviceErr != nil {
+		return nil, nil, serviceErr
+	}
+
+	ids := make([]int, 0, len(updates))
+	seen := make(map[int]bool, len(updates))
+	for _, update := range updates {
+		if seen[update.ID] {
+			return nil, nil, &T.ServiceError{
+				Message:     fmt.Sprintf("Product %d is updated more than once", update.ID),
+				MessageCode: T.MsgDocumentInvalid,
+				Error:       errors.New("duplicate id in bulk update"),
+				Code:        fiber.StatusBadRequest,
+			}
+		}
+		seen[update.ID] = true
+		ids = append(ids, update.ID)
+	}
+
+	locked, serviceErr := s.GetProductsByIDsForUpdate(dbTrx, ctx, ids)
+	if serviceErr != nil {
+		return nil, nil, serviceErr
+	}
+
+	byID := make(map[int]*M.Product, len(locked))
+	for _, product := range locked {
+		byID[product.ID] = product
+	}
+
+	updated := make([]*M.Product, 0, len(locked))
+	records := make([]AuditRecord, 0, len(locked))
+	missing := []int{}
+
+	for i, update := range updates {
+		before, ok := byID[update.ID]
+		if !ok {
+			missing = append(missing, update.ID)
+			continue
+		}
+
+		body := &ProductBody{
+			Name:        before.Name,
+			Description: before.Description.String,
+			Price:       Price{before.Price},
+		}
+		if update.Name != nil {
+			body.Name = *update.Name
+		}
+		if update.Description != nil {
+			body.Description = *update.Description
+		}
+		if update.Price != nil {
+			body.Price = *update.Price
+		}
+
+		body = s.prepareBody(body)
+
+		serviceErr := body.Validate()
+		if serviceErr == nil {
+			serviceErr = s.checkBodyConfig(body)
+		}
+		if serviceErr == nil {
+			serviceErr = s.runValidators(ctx, body)
+		}
+		if serviceErr != nil {
+			itemErr := *serviceErr
+			itemErr.Message = fmt.Sprintf("Update %d (product %d): %s", i, update.ID, serviceErr.Message)
+			return nil, nil, &itemErr
+		}
+
+		after := *before
+		s.applyBody(body, &after)
+
+		updated = append(updated, &after)
+		records = append(records, AuditRecord{Action: AuditActionUpdate, ProductID: after.ID, Before: before, After: &after})
+	}
+
+	for start := 0; start < len(updated); start += C.IMPORT_BATCH_SIZE {
+		end := min(start+C.IMPORT_BATCH_SIZE, len(updated))
+
+		if err := updateProductBatch(dbTrx, ctx, updated[start:end]); err != nil {
+			return nil, nil, &T.ServiceError{
+				Message: "Unable to update products",
+				Error:   err,
+				Code:    dbErrorStatus(err),
+			}
+		}
+	}
+
+	if serviceErr := s.auditMany(ctx, dbTrx, records); serviceErr != nil {
+		return nil, nil, serviceErr
+	}
+
+	for _, product := range updated {
+		s.invalidateCache(ctx, product.ID)
+	}
+
+	return updated, missing, nil
+}
+
+// updateProductBatch writes the name, description and price of every product
+// with a single UPDATE ... FROM (VALUES ...).
+func updateProductBatch(dbTrx boil.ContextExecutor, ctx context.Context, products []*M.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(products))
+	args := make([]interface{}, 0, len(products)*4)
+
+	for i, product := range products {
+		values = append(values, fmt.Sprintf("($%d::integer, $%d::text, $%d::text, $%d::numeric)", i*4+1, i*4+2, i*4+3, i*4+4))
+		args = append(args, product.ID, product.Name, product.Description, product.Price)
+	}
+
+	query := fmt.Sprintf(
+		"UPDATE %[1]s SET %[2]s = v.name, %[3]s = v.description, %[4]s = v.price FROM (VALUES %[6]s) AS v(id, name, description, price) WHERE %[1]s.%[5]s = v.id",
+		M.TableNames.Products,
+		M.ProductColumns.Name,
+		M.ProductColumns.Description,
+		M.ProductColumns.Price,
+		M.ProductColumns.ID,
+		strings.Join(values, ", "),
+	)
+
+	_, err := queries.Raw(query, args...).ExecContext(ctx, dbTrx)
+	return err
+}
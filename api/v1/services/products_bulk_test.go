@@ -0,0 +1,58 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	S "github.com/atharvbhadange/go-api-template/api/v1/services"
+	"github.com/atharvbhadange/go-api-template/api/v1/services/testutil"
+	C "github.com/atharvbhadange/go-api-template/constants"
+)
+
+func TestBulkUpdateProductsLargeBatch(t *testing.T) {
+	const count = 14000
+
+	service, err := S.NewProductService(S.ProductServiceConfig{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := testutil.IDRange(1, count)
+	fake := testutil.NewFakeExecutor()
+
+	for start := 0; start < count; start += service.Config.IDsChunkSize {
+		end := min(start+service.Config.IDsChunkSize, count)
+		fake.Push(testutil.ProductsResult(ids[start:end]...))
+	}
+	for i := 0; i < count/C.IMPORT_BATCH_SIZE+count/C.INSERT_BATCH_SIZE; i++ {
+		fake.Push(testutil.FakeResult{})
+	}
+
+	name := "Renamed"
+	updates := make([]S.ProductUpdate, count)
+	for i, id := range ids {
+		updates[i] = S.ProductUpdate{ID: id, Name: &name}
+	}
+
+	updated, missing, serviceErr := service.BulkUpdateProducts(fake, context.Background(), updates)
+	if serviceErr != nil {
+		t.Fatalf("BulkUpdateProducts() = %q: %v", serviceErr.Message, serviceErr.Error)
+	}
+	if len(updated) != count || len(missing) != 0 {
+		t.Fatalf("BulkUpdateProducts() updated %d, missing %d; want %d and 0", len(updated), len(missing), count)
+	}
+
+	audited := 0
+	for _, call := range fake.Calls() {
+		if len(call.Args) > 65535 {
+			t.Fatalf("statement has %d parameters, over the Postgres limit", len(call.Args))
+		}
+		if strings.HasPrefix(call.Query, "INSERT INTO audit_log") {
+			audited += len(call.Args) / 5
+		}
+	}
+	if audited != count {
+		t.Fatalf("audited %d updates, want %d", audited, count)
+	}
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	C "github.com/atharvbhadange/go-api-template/constants"
+	M "github.com/atharvbhadange/go-api-template/models"
+	T "github.com/atharvbhadange/go-api-template/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ComparisonRow struct {
+	Attribute string        `json:"attribute"`
+	Values    []interface{} `json:"values"`
+}
+
+type ProductComparison struct {
+	ProductIDs []int           `json:"product_ids"`
+	Rows       []ComparisonRow `json:"rows"`
+}
+
+var comparisonAttributes = []struct {
+	name  string
+	value func(product *M.Product) interface{}
+}{
+	{M.ProductColumns.Name, func(product *M.Product) interface{} { return product.Name }},
+	{M.ProductColumns.Price, func(product *M.Product) interface{} { return product.Price.String() }},
+	{M.ProductColumns.Description, func(product *M.Product) interface{} { return nullStringValue(product.Description) }},
+}
+
+// CompareProducts returns the requested products as attribute rows with one value per product,
+// in the order the ids were given. Attributes a product lacks are reported as nil.
+func (s *ProductService) CompareProducts(dbTrx boil.ContextExecutor, ctx context.Context, ids []int) (*ProductComparison, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	uniqueIDs := uniqueProductIDs(ids)
+
+	if len(uniqueIDs) == 0 {
+		return nil, &T.ServiceError{
+			Message:     "At least one product id is required",
+			MessageCode: T.MsgIDsRequired,
+			Error:       errors.New("no product ids"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	if len(uniqueIDs) > C.COMPARE_PRODUCTS_MAX {
+		return nil, &T.ServiceError{
+			Message:     fmt.Sprintf("Cannot compare more than %d products", C.COMPARE_PRODUCTS_MAX),
+			MessageCode: T.MsgTooManyIDs,
+			Error:       errors.New("too many product ids"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	products, err := M.Products(M.ProductWhere.ID.IN(uniqueIDs)).All(ctx, dbTrx)
+	if err != nil {
+		return nil, &T.ServiceError{
+			Message: "Unable to get products",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	byID := make(map[int]*M.Product, len(products))
+	for _, product := range products {
+		byID[product.ID] = product
+	}
+
+	for _, id := range uniqueIDs {
+		if byID[id] == nil {
+			return nil, &T.ServiceError{
+				Message:     fmt.Sprintf("Product %d not found", id),
+				MessageCode: T.MsgProductNotFound,
+				Error:       errors.New("product not found"),
+				Code:        fiber.StatusNotFound,
+			}
+		}
+	}
+
+	comparison := &ProductComparison{
+		ProductIDs: uniqueIDs,
+		Rows:       make([]ComparisonRow, 0, len(comparisonAttributes)),
+	}
+
+	for _, attribute := range comparisonAttributes {
+		row := ComparisonRow{Attribute: attribute.name, Values: make([]interface{}, len(uniqueIDs))}
+		for i, id := range uniqueIDs {
+			row.Values[i] = attribute.value(byID[id])
+		}
+		comparison.Rows = append(comparison.Rows, row)
+	}
+
+	return comparison, nil
+}
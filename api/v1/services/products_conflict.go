@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	M "github.com/atharvbhadange/go-api-template/models"
+	T "github.com/atharvbhadange/go-api-template/types"
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+)
+
+const pqUniqueViolation = "23505"
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation
+}
+
+// insertOrGetExisting inserts product and, when a concurrent create won the race
+// for the same unique name, returns that row with created false instead of
+// failing. Postgres aborts the transaction on a failed insert, so the insert
+// runs under a savepoint that the conflict path rolls back to before looking
+// the winner up. dbTrx must be a transaction.
+func (s *ProductService) insertOrGetExisting(dbTrx boil.ContextExecutor, ctx context.Context, product *M.Product) (*M.Product, bool, *T.ServiceError) {
+	if _, err := queries.Raw("SAVEPOINT create_product").ExecContext(ctx, dbTrx); err != nil {
+		return nil, false, &T.ServiceError{
+			Message: "Unable to create product",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	serviceErr := s.insertProduct(dbTrx, ctx, product)
+	if serviceErr == nil {
+		if _, err := queries.Raw("RELEASE SAVEPOINT create_product").ExecContext(ctx, dbTrx); err != nil {
+			return nil, false, &T.ServiceError{
+				Message: "Unable to create product",
+				Error:   err,
+				Code:    dbErrorStatus(err),
+			}
+		}
+		return product, true, nil
+	}
+
+	if serviceErr.Code != fiber.StatusConflict {
+		return nil, false, serviceErr
+	}
+
+	if _, err := queries.Raw("ROLLBACK TO SAVEPOINT create_product").ExecContext(ctx, dbTrx); err != nil {
+		return nil, false, &T.ServiceError{
+			Message: "Unable to create product",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	existing, err := M.Products(M.ProductWhere.Name.EQ(product.Name)).One(ctx, dbTrx)
+	if err != nil {
+		return nil, false, &T.ServiceError{
+			Message: "Unable to get existing product",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	return existing, false, nil
+}
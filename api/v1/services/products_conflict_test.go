@@ -0,0 +1,63 @@
+package services_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	S "github.com/atharvbhadange/go-api-template/api/v1/services"
+	"github.com/atharvbhadange/go-api-template/api/v1/services/testutil"
+	"github.com/lib/pq"
+)
+
+func TestCreateOrGetProductReportsCreated(t *testing.T) {
+	tests := []struct {
+		name        string
+		results     []testutil.FakeResult
+		wantID      int
+		wantCreated bool
+	}{
+		{
+			name: "inserted",
+			results: []testutil.FakeResult{
+				{},
+				{Columns: []string{"id"}, Rows: [][]driver.Value{{int64(1)}}},
+				{},
+				{RowsAffected: 1},
+			},
+			wantID:      1,
+			wantCreated: true,
+		},
+		{
+			// The hooks for a create must not fire for a row someone else created.
+			name: "lost the race",
+			results: []testutil.FakeResult{
+				{},
+				{Err: &pq.Error{Code: "23505"}},
+				{},
+				testutil.ProductsResult(5),
+			},
+			wantID: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, err := S.NewProductService(S.ProductServiceConfig{ReturnExistingOnConflict: true}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			fake := testutil.NewFakeExecutor()
+			fake.Push(tt.results...)
+
+			product, created, serviceErr := service.CreateOrGetProduct(fake, context.Background(), testutil.NewProductBody().Build())
+			if serviceErr != nil {
+				t.Fatalf("CreateOrGetProduct() = %q: %v", serviceErr.Message, serviceErr.Error)
+			}
+			if product.ID != tt.wantID || created != tt.wantCreated {
+				t.Fatalf("CreateOrGetProduct() = product %d, created %v; want %d, %v", product.ID, created, tt.wantID, tt.wantCreated)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	M "github.com/atharvbhadange/go-api-template/models"
+	T "github.com/atharvbhadange/go-api-template/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+// CountProductsMatching returns how many products filter matches. It is the
+// first half of a bulk delete: show the count to the user, then pass it to
+// DeleteProductsMatching.
+func (s *ProductService) CountProductsMatching(dbTrx boil.ContextExecutor, ctx context.Context, filter ProductFilter) (int64, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	count, err := M.Products(filter.queryMods()...).Count(ctx, dbTrx)
+	if err != nil {
+		return 0, &T.ServiceError{
+			Message: "Unable to count products",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	return count, nil
+}
+
+// DeleteProductsMatching deletes every product filter matches, but only when
+// that is exactly expectedCount products; otherwise nothing is deleted and it
+// fails with 409. This catches a filter that matches more than the user
+// confirmed, whether from a bug or rows added since the count. The matching
+// rows are locked before they are counted, so dbTrx should be a transaction.
+// The deleted products are returned in id order.
+func (s *ProductService) DeleteProductsMatching(dbTrx boil.ContextExecutor, ctx context.Context, filter ProductFilter, expectedCount int64) ([]*M.Product, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if expectedCount < 0 {
+		return nil, &T.ServiceError{
+			Message: "Expected count cannot be negative",
+			Error:   errors.New("negative expected count"),
+			Code:    fiber.StatusBadRequest,
+		}
+	}
+
+	if serviceErr := checkMaintenance(); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	mods := append(filter.queryMods(), qm.OrderBy(M.ProductTableColumns.ID), qm.For("UPDATE"))
+
+	products, err := M.Products(mods...).All(ctx, dbTrx)
+	if err != nil {
+		return nil, &T.ServiceError{
+			Message: "Unable to get products",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	if int64(len(products)) != expectedCount {
+		return nil, &T.ServiceError{
+			Message:     fmt.Sprintf("Filter matches %d products, expected %d", len(products), expectedCount),
+			MessageCode: T.MsgDeleteCountMismatch,
+			Error:       errors.New("delete count mismatch"),
+			Code:        fiber.StatusConflict,
+		}
+	}
+
+	if len(products) == 0 {
+		return products, nil
+	}
+
+	ids := make([]int, len(products))
+	records := make([]AuditRecord, len(products))
+	for i, product := range products {
+		ids[i] = product.ID
+		records[i] = AuditRecord{Action: AuditActionDelete, ProductID: product.ID, Before: product}
+	}
+
+	chunkSize := s.idsChunkSize()
+
+	for start := 0; start < len(ids); start += chunkSize {
+		end := min(start+chunkSize, len(ids))
+
+		if _, err := M.Products(M.ProductWhere.ID.IN(ids[start:end])).DeleteAll(ctx, dbTrx); err != nil {
+			return nil, &T.ServiceError{
+				Message: "Unable to delete products",
+				Error:   err,
+				Code:    dbErrorStatus(err),
+			}
+		}
+	}
+
+	if serviceErr := s.auditMany(ctx, dbTrx, records); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	if s.Config.Cache != nil {
+		if err := s.Config.Cache.Delete(ctx, ids); err != nil {
+			s.logger().Printf("product cache invalidation failed for %d products: %v", len(ids), err)
+		}
+	}
+
+	return products, nil
+}
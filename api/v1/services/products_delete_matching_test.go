@@ -0,0 +1,48 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	S "github.com/atharvbhadange/go-api-template/api/v1/services"
+	"github.com/atharvbhadange/go-api-template/api/v1/services/testutil"
+)
+
+// A delete matching more rows than fit in one statement's bind parameters must
+// split every write, the audit entries included.
+func TestDeleteProductsMatchingLargeMatch(t *testing.T) {
+	const matched = 14000
+
+	service, err := S.NewProductService(S.ProductServiceConfig{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake := testutil.NewFakeExecutor()
+	fake.Push(testutil.ProductsResult(testutil.IDRange(1, matched)...))
+	for i := 0; i < 2*matched/service.Config.IDsChunkSize; i++ {
+		fake.Push(testutil.FakeResult{})
+	}
+
+	deleted, serviceErr := service.DeleteProductsMatching(fake, context.Background(), S.ProductFilter{Name: "Product"}, matched)
+	if serviceErr != nil {
+		t.Fatalf("DeleteProductsMatching() = %q: %v", serviceErr.Message, serviceErr.Error)
+	}
+	if len(deleted) != matched {
+		t.Fatalf("DeleteProductsMatching() deleted %d products, want %d", len(deleted), matched)
+	}
+
+	audited := 0
+	for _, call := range fake.Calls() {
+		if len(call.Args) > 65535 {
+			t.Fatalf("statement has %d parameters, over the Postgres limit", len(call.Args))
+		}
+		if strings.HasPrefix(call.Query, "INSERT INTO audit_log") {
+			audited += len(call.Args) / 5
+		}
+	}
+	if audited != matched {
+		t.Fatalf("audited %d deletes, want %d", audited, matched)
+	}
+}
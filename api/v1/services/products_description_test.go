@@ -0,0 +1,156 @@
+package services_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	S "github.com/atharvbhadange/go-api-template/api/v1/services"
+	"github.com/atharvbhadange/go-api-template/api/v1/services/testutil"
+	M "github.com/atharvbhadange/go-api-template/models"
+	T "github.com/atharvbhadange/go-api-template/types"
+)
+
+// emptyDescriptionCases are the outcomes every write path must agree on for an
+// empty description.
+var emptyDescriptionCases = []struct {
+	name       string
+	policy     S.EmptyDescriptionPolicy
+	wantNull   bool
+	wantReject bool
+}{
+	{name: "null", policy: S.EmptyDescriptionNull, wantNull: true},
+	{name: "string", policy: S.EmptyDescriptionString},
+	{name: "reject", policy: S.EmptyDescriptionReject, wantReject: true},
+}
+
+func TestCreateProductEmptyDescription(t *testing.T) {
+	for _, tt := range emptyDescriptionCases {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newDescriptionService(t, tt.policy)
+
+			// sqlboiler reads a NULL column back with RETURNING, as it has a default.
+			inserted := testutil.FakeResult{Columns: []string{"id"}, Rows: [][]driver.Value{{int64(1)}}}
+			if tt.wantNull {
+				inserted = testutil.FakeResult{Columns: []string{"id", "description"}, Rows: [][]driver.Value{{int64(1), nil}}}
+			}
+
+			fake := testutil.NewFakeExecutor()
+			fake.Push(inserted, testutil.FakeResult{RowsAffected: 1})
+
+			body := testutil.NewProductBody().WithDescription("").Build()
+
+			product, serviceErr := service.CreateProduct(fake, context.Background(), body)
+			checkEmptyDescription(t, fake, "INSERT INTO \"products\"", product, serviceErr, tt.wantNull, tt.wantReject)
+		})
+	}
+}
+
+func TestUpdateProductEmptyDescription(t *testing.T) {
+	for _, tt := range emptyDescriptionCases {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newDescriptionService(t, tt.policy)
+
+			fake := testutil.NewFakeExecutor()
+			fake.Push(
+				testutil.ProductsResult(1),
+				testutil.FakeResult{RowsAffected: 1},
+				testutil.FakeResult{RowsAffected: 1},
+			)
+
+			body := testutil.NewProductBody().WithDescription("").Build()
+
+			product, _, _, serviceErr := service.UpdateProduct(fake, context.Background(), 1, body, true)
+			checkEmptyDescription(t, fake, "UPDATE \"products\"", product, serviceErr, tt.wantNull, tt.wantReject)
+		})
+	}
+}
+
+func TestMergePatchProductEmptyDescription(t *testing.T) {
+	for _, document := range []string{`{"description": ""}`, `{"description": null}`} {
+		for _, tt := range emptyDescriptionCases {
+			t.Run(tt.name+" "+document, func(t *testing.T) {
+				service := newDescriptionService(t, tt.policy)
+
+				fake := testutil.NewFakeExecutor()
+				fake.Push(
+					testutil.ProductsResult(1),
+					testutil.FakeResult{RowsAffected: 1},
+					testutil.FakeResult{RowsAffected: 1},
+				)
+
+				product, _, _, serviceErr := service.MergePatchProduct(fake, context.Background(), 1, json.RawMessage(document), false)
+				checkEmptyDescription(t, fake, "UPDATE \"products\"", product, serviceErr, tt.wantNull, tt.wantReject)
+			})
+		}
+	}
+}
+
+func TestApplyToEmptyDescription(t *testing.T) {
+	for _, tt := range emptyDescriptionCases {
+		if tt.wantReject {
+			continue
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			product := &M.Product{}
+			testutil.NewProductBody().WithDescription("").Build().ApplyTo(product, tt.policy)
+
+			if product.Description.Valid == tt.wantNull {
+				t.Fatalf("ApplyTo() stored description %+v, want null = %v", product.Description, tt.wantNull)
+			}
+		})
+	}
+}
+
+func newDescriptionService(t *testing.T, policy S.EmptyDescriptionPolicy) *S.ProductService {
+	t.Helper()
+
+	service, err := S.NewProductService(S.ProductServiceConfig{EmptyDescription: policy}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return service
+}
+
+// checkEmptyDescription checks both the returned product and the value the
+// write statement, the first call whose query starts with write, sent.
+func checkEmptyDescription(t *testing.T, fake *testutil.FakeExecutor, write string, product *M.Product, serviceErr *T.ServiceError, wantNull, wantReject bool) {
+	t.Helper()
+
+	if wantReject {
+		if serviceErr == nil || serviceErr.MessageCode != T.MsgProductDescriptionRequired {
+			t.Fatalf("got %+v, want %s", serviceErr, T.MsgProductDescriptionRequired)
+		}
+		for _, call := range fake.Calls() {
+			if strings.HasPrefix(call.Query, write) {
+				t.Fatalf("rejected write still ran %q", call.Query)
+			}
+		}
+		return
+	}
+
+	if serviceErr != nil {
+		t.Fatalf("got %q: %v", serviceErr.Message, serviceErr.Error)
+	}
+	if product.Description.Valid == wantNull {
+		t.Fatalf("returned description %+v, want null = %v", product.Description, wantNull)
+	}
+
+	for _, call := range fake.Calls() {
+		if !strings.HasPrefix(call.Query, write) {
+			continue
+		}
+		for _, arg := range call.Args {
+			if wantNull && arg == "" {
+				t.Fatalf("%q sent an empty string, want NULL", call.Query)
+			}
+			if !wantNull && arg == nil {
+				t.Fatalf("%q sent NULL, want an empty string", call.Query)
+			}
+		}
+		return
+	}
+	t.Fatalf("no statement starting with %q ran", write)
+}
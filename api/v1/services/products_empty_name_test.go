@@ -0,0 +1,101 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	S "github.com/atharvbhadange/go-api-template/api/v1/services"
+	"github.com/atharvbhadange/go-api-template/api/v1/services/testutil"
+	M "github.com/atharvbhadange/go-api-template/models"
+	T "github.com/atharvbhadange/go-api-template/types"
+)
+
+var emptyNameCases = []struct {
+	name       string
+	policy     S.EmptyNamePolicy
+	blank      string
+	wantName   string
+	wantReject bool
+}{
+	{name: "reject empty", policy: S.EmptyNameReject, blank: "", wantReject: true},
+	{name: "reject whitespace", policy: S.EmptyNameReject, blank: "   ", wantReject: true},
+	{name: "keep empty", policy: S.EmptyNameKeep, blank: "", wantName: "Product 1"},
+	{name: "keep whitespace", policy: S.EmptyNameKeep, blank: "   ", wantName: "Product 1"},
+	{name: "allow empty", policy: S.EmptyNameAllow, blank: "", wantName: ""},
+}
+
+func TestUpdateProductEmptyName(t *testing.T) {
+	for _, tt := range emptyNameCases {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := emptyNameExecutor()
+
+			body := testutil.NewProductBody().WithName(tt.blank).Build()
+
+			product, _, _, serviceErr := newEmptyNameService(t, tt.policy).UpdateProduct(fake, context.Background(), 1, body, true)
+			checkEmptyName(t, fake, product, serviceErr, tt.wantName, tt.wantReject)
+		})
+	}
+}
+
+func TestMergePatchProductEmptyName(t *testing.T) {
+	for _, tt := range emptyNameCases {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := emptyNameExecutor()
+
+			document, err := json.Marshal(map[string]string{"name": tt.blank})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			product, _, _, serviceErr := newEmptyNameService(t, tt.policy).MergePatchProduct(fake, context.Background(), 1, document, false)
+			checkEmptyName(t, fake, product, serviceErr, tt.wantName, tt.wantReject)
+		})
+	}
+}
+
+func newEmptyNameService(t *testing.T, policy S.EmptyNamePolicy) *S.ProductService {
+	t.Helper()
+
+	service, err := S.NewProductService(S.ProductServiceConfig{EmptyNameOnUpdate: policy}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return service
+}
+
+// emptyNameExecutor answers the load, update and audit insert of an update of
+// product 1.
+func emptyNameExecutor() *testutil.FakeExecutor {
+	fake := testutil.NewFakeExecutor()
+	fake.Push(
+		testutil.ProductsResult(1),
+		testutil.FakeResult{RowsAffected: 1},
+		testutil.FakeResult{RowsAffected: 1},
+	)
+	return fake
+}
+
+func checkEmptyName(t *testing.T, fake *testutil.FakeExecutor, product *M.Product, serviceErr *T.ServiceError, wantName string, wantReject bool) {
+	t.Helper()
+
+	if wantReject {
+		if serviceErr == nil || serviceErr.MessageCode != T.MsgProductNameRequired {
+			t.Fatalf("got %+v, want %s", serviceErr, T.MsgProductNameRequired)
+		}
+		for _, call := range fake.Calls() {
+			if strings.HasPrefix(call.Query, "UPDATE") {
+				t.Fatalf("rejected update still ran %q", call.Query)
+			}
+		}
+		return
+	}
+
+	if serviceErr != nil {
+		t.Fatalf("got %q: %v", serviceErr.Message, serviceErr.Error)
+	}
+	if product.Name != wantName {
+		t.Fatalf("stored name %q, want %q", product.Name, wantName)
+	}
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	M "github.com/atharvbhadange/go-api-template/models"
+	T "github.com/atharvbhadange/go-api-template/types"
+	"github.com/lib/pq"
+)
+
+// ExportContentEncoding is the Content-Encoding handlers must set when an
+// export is written with compress enabled.
+const ExportContentEncoding = "gzip"
+
+// Rows written between gzip flushes. Flushing every row would defeat compression.
+const exportGzipFlushEvery = 100
+
+// ExportProductsJSONL writes every product to w as one JSON object per line,
+// scanning rows one at a time so the catalog is never held in memory.
+// With compress set the output is gzip encoded, see ExportContentEncoding.
+func (s *ProductService) ExportProductsJSONL(dbTrx boil.ContextExecutor, ctx context.Context, w io.Writer, compress bool) *T.ServiceError {
+	out := newExportWriter(w, compress)
+
+	err := eachProduct(dbTrx, ctx, func(product *M.Product) error {
+		line, err := json.Marshal(product)
+		if err != nil {
+			return err
+		}
+
+		return out.write(append(line, '\n'))
+	}, qm.OrderBy(M.ProductColumns.ID))
+
+	if closeErr := out.close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return &T.ServiceError{
+			Message: "Unable to export products",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	return nil
+}
+
+// StreamProductsJSON writes every product to w as a single JSON array, encoding
+// and flushing one element at a time so the array is never buffered. Nothing is
+// written until the first row has been read, so a failed query can still be
+// reported with an error status. A failure after that leaves the array
+// unterminated: the client gets an invalid document rather than a short valid one.
+func (s *ProductService) StreamProductsJSON(dbTrx boil.ContextExecutor, ctx context.Context, w io.Writer) *T.ServiceError {
+	encoder := json.NewEncoder(w)
+	written := 0
+
+	err := eachProduct(dbTrx, ctx, func(product *M.Product) error {
+		separator := []byte(",")
+		if written == 0 {
+			separator = []byte("[")
+		}
+		if _, err := w.Write(separator); err != nil {
+			return err
+		}
+
+		if err := encoder.Encode(product); err != nil {
+			return err
+		}
+		written++
+
+		return flushWriter(w)
+	}, qm.OrderBy(M.ProductColumns.ID))
+
+	if err == nil {
+		closing := []byte("]")
+		if written == 0 {
+			closing = []byte("[]")
+		}
+		if _, err = w.Write(closing); err == nil {
+			err = flushWriter(w)
+		}
+	}
+
+	if err != nil {
+		return &T.ServiceError{
+			Message: "Unable to stream products",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	return nil
+}
+
+// StreamProductsByIDs calls fn for each of the given products as its row is
+// scanned, so rendering can start before the whole batch has been read. Rows
+// arrive in input order, one IDsChunkSize query at a time, with duplicates and
+// missing ids skipped. Returning means the stream is complete: nil after the
+// last row, or the error that stopped it. An error returned by fn stops the
+// stream and is passed back as the ServiceError's Error.
+func (s *ProductService) StreamProductsByIDs(dbTrx boil.ContextExecutor, ctx context.Context, ids []int, fn func(product *M.Product) error) *T.ServiceError {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	uniqueIDs := uniqueProductIDs(ids)
+	chunkSize := s.idsChunkSize()
+
+	for start := 0; start < len(uniqueIDs); start += chunkSize {
+		chunk := pq.Array(uniqueIDs[start:min(start+chunkSize, len(uniqueIDs))])
+
+		err := eachProduct(dbTrx, ctx, fn,
+			qm.Where(M.ProductTableColumns.ID+" = ANY(?)", chunk),
+			qm.OrderBy("array_position(?::integer[], "+M.ProductTableColumns.ID+")", chunk),
+		)
+		if err != nil {
+			return &T.ServiceError{
+				Message: "Unable to stream products",
+				Error:   err,
+				Code:    dbErrorStatus(err),
+			}
+		}
+	}
+
+	return nil
+}
+
+// eachProduct runs the query and calls fn for every row as it is scanned.
+func eachProduct(dbTrx boil.ContextExecutor, ctx context.Context, fn func(product *M.Product) error, mods ...qm.QueryMod) error {
+	mods = append([]qm.QueryMod{qm.Select(
+		M.ProductTableColumns.ID,
+		M.ProductTableColumns.Name,
+		M.ProductTableColumns.Price,
+		M.ProductTableColumns.Description,
+	)}, mods...)
+
+	rows, err := M.Products(mods...).QueryContext(ctx, dbTrx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		product := &M.Product{}
+
+		if err := rows.Scan(&product.ID, &product.Name, &product.Price, &product.Description); err != nil {
+			return err
+		}
+
+		if err := fn(product); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+type exportWriter struct {
+	dst  io.Writer
+	gz   *gzip.Writer
+	rows int
+}
+
+func newExportWriter(w io.Writer, compress bool) *exportWriter {
+	out := &exportWriter{dst: w}
+	if compress {
+		out.gz = gzip.NewWriter(w)
+	}
+	return out
+}
+
+// write writes one complete chunk and flushes it through to the destination.
+func (out *exportWriter) write(chunk []byte) error {
+	if out.gz == nil {
+		if _, err := out.dst.Write(chunk); err != nil {
+			return err
+		}
+		return flushWriter(out.dst)
+	}
+
+	if _, err := out.gz.Write(chunk); err != nil {
+		return err
+	}
+
+	out.rows++
+	if out.rows%exportGzipFlushEvery != 0 {
+		return nil
+	}
+
+	if err := out.gz.Flush(); err != nil {
+		return err
+	}
+	return flushWriter(out.dst)
+}
+
+func (out *exportWriter) close() error {
+	if out.gz != nil {
+		if err := out.gz.Close(); err != nil {
+			return err
+		}
+	}
+	return flushWriter(out.dst)
+}
+
+func flushWriter(w io.Writer) error {
+	switch f := w.(type) {
+	case interface{ Flush() error }:
+		return f.Flush()
+	case interface{ Flush() }:
+		f.Flush()
+	}
+	return nil
+}
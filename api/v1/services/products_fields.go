@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	M "github.com/atharvbhadange/go-api-template/models"
+	T "github.com/atharvbhadange/go-api-template/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type UnknownFieldMode int
+
+const (
+	UnknownFieldsReject UnknownFieldMode = iota
+	UnknownFieldsIgnore
+)
+
+// productFieldValues maps each selectable column to its value on a loaded product,
+// keeping the same JSON representation as the full model.
+var productFieldValues = map[string]func(product *M.Product) interface{}{
+	M.ProductColumns.ID:          func(product *M.Product) interface{} { return product.ID },
+	M.ProductColumns.Name:        func(product *M.Product) interface{} { return product.Name },
+	M.ProductColumns.Price:       func(product *M.Product) interface{} { return product.Price },
+	M.ProductColumns.Description: func(product *M.Product) interface{} { return product.Description },
+}
+
+// ParseProductFields validates a requested field set against the product columns,
+// dropping duplicates and handling unknown names per Config.UnknownFieldMode.
+func (s *ProductService) ParseProductFields(fields []string) ([]string, *T.ServiceError) {
+	parsed := []string{}
+	seen := map[string]bool{}
+
+	for _, field := range fields {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field == "" || seen[field] {
+			continue
+		}
+
+		if _, ok := productFieldValues[field]; !ok {
+			if s.Config.UnknownFieldMode == UnknownFieldsIgnore {
+				continue
+			}
+			return nil, &T.ServiceError{
+				Message:     "Unknown field " + field,
+				MessageCode: T.MsgFieldUnknown,
+				Error:       errors.New("unknown product field"),
+				Code:        fiber.StatusBadRequest,
+			}
+		}
+
+		seen[field] = true
+		parsed = append(parsed, field)
+	}
+
+	if len(parsed) == 0 {
+		return nil, &T.ServiceError{
+			Message:     "At least one valid field is required",
+			MessageCode: T.MsgFieldsRequired,
+			Error:       errors.New("empty field set"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	return parsed, nil
+}
+
+// GetProductsWithFields lists every product with only the requested columns,
+// cut off at SoftResultLimit like GetProducts.
+func (s *ProductService) GetProductsWithFields(dbTrx boil.ContextExecutor, ctx context.Context, fields []string) ([]map[string]interface{}, ListMeta, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	columns, serviceErr := s.ParseProductFields(fields)
+	if serviceErr != nil {
+		return nil, ListMeta{}, serviceErr
+	}
+
+	mods := append([]qm.QueryMod{qm.Select(columns...), qm.OrderBy(M.ProductTableColumns.ID)}, s.softLimitMods()...)
+
+	products, err := M.Products(mods...).All(ctx, dbTrx)
+	if err != nil {
+		return nil, ListMeta{}, &T.ServiceError{
+			Message: "Unable to get products",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	products, meta, err := applySoftLimit(s, dbTrx, ctx, products, nil)
+	if err != nil {
+		return nil, ListMeta{}, &T.ServiceError{
+			Message: "Unable to count products",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	return sparseProducts(products, columns), meta, nil
+}
+
+func (s *ProductService) GetProductWithFields(dbTrx boil.ContextExecutor, ctx context.Context, id int, fields []string) (map[string]interface{}, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if serviceErr := validateProductID(id); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	columns, serviceErr := s.ParseProductFields(fields)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	product, err := M.FindProduct(ctx, dbTrx, id, columns...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &T.ServiceError{
+				Message:     "Product not found",
+				MessageCode: T.MsgProductNotFound,
+				Error:       err,
+				Code:        fiber.StatusNotFound,
+			}
+		}
+		return nil, &T.ServiceError{
+			Message: "Unable to get product",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	return sparseProduct(product, columns), nil
+}
+
+// GetProductsByIDsWithFields loads only the requested columns of only the given
+// products, in one query. Results follow the order of the first occurrence of
+// each id and missing ids are skipped, as in GetProductsByIDs. Since it is a
+// single IN list, at most IDsChunkSize distinct ids are accepted.
+func (s *ProductService) GetProductsByIDsWithFields(dbTrx boil.ContextExecutor, ctx context.Context, ids []int, fields []string) ([]map[string]interface{}, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	columns, serviceErr := s.ParseProductFields(fields)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	uniqueIDs := uniqueProductIDs(ids)
+
+	if len(uniqueIDs) > s.idsChunkSize() {
+		return nil, &T.ServiceError{
+			Message:     fmt.Sprintf("Cannot request more than %d ids", s.idsChunkSize()),
+			MessageCode: T.MsgTooManyIDs,
+			Error:       errors.New("too many ids"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	if len(uniqueIDs) == 0 {
+		return []map[string]interface{}{}, nil
+	}
+
+	// id is needed to put the rows back in request order even when not asked for.
+	selected := columns
+	if !slices.Contains(columns, M.ProductColumns.ID) {
+		selected = append([]string{M.ProductColumns.ID}, columns...)
+	}
+
+	products, err := M.Products(qm.Select(selected...), M.ProductWhere.ID.IN(uniqueIDs)).All(ctx, dbTrx)
+	if err != nil {
+		return nil, &T.ServiceError{
+			Message: "Unable to get products",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	byID := make(map[int]*M.Product, len(products))
+	for _, product := range products {
+		byID[product.ID] = product
+	}
+
+	results := make([]map[string]interface{}, 0, len(products))
+	for _, id := range uniqueIDs {
+		if product, ok := byID[id]; ok {
+			results = append(results, sparseProduct(product, columns))
+		}
+	}
+
+	return results, nil
+}
+
+func sparseProducts(products []*M.Product, columns []string) []map[string]interface{} {
+	results := make([]map[string]interface{}, 0, len(products))
+	for _, product := range products {
+		results = append(results, sparseProduct(product, columns))
+	}
+	return results
+}
+
+func sparseProduct(product *M.Product, columns []string) map[string]interface{} {
+	result := make(map[string]interface{}, len(columns))
+	for _, column := range columns {
+		result[column] = productFieldValues[column](product)
+	}
+	return result
+}
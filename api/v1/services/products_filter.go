@@ -0,0 +1,126 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	M "github.com/atharvbhadange/go-api-template/models"
+	T "github.com/atharvbhadange/go-api-template/types"
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+)
+
+type ProductFilter struct {
+	Name     string           `json:"name"`
+	MinPrice *decimal.Decimal `json:"min_price"`
+	MaxPrice *decimal.Decimal `json:"max_price"`
+
+	// Only used by the paginated functions. Page is 1-based and 0 means the first
+	// page. PageSize 0 means the service DefaultPageSize.
+	Page     int `json:"page"`
+	PageSize int `json:"page_size"`
+
+	// Column to order by, prefixed with "-" for descending, e.g. "-price".
+	// "name_natural" sorts names with embedded numbers in numeric order.
+	Sort string `json:"sort"`
+
+	// Shuffles the paginated order instead of sorting: the same seed gives the
+	// same order on every page. Cannot be combined with Sort.
+	Seed string `json:"seed"`
+}
+
+func (filter ProductFilter) IsEmpty() bool {
+	return strings.TrimSpace(filter.Name) == "" && filter.MinPrice == nil && filter.MaxPrice == nil
+}
+
+// queryMods translates the filter into WHERE clauses. Name is a case-insensitive
+// substring match and the price bounds are inclusive.
+func (filter ProductFilter) queryMods() []qm.QueryMod {
+	mods := []qm.QueryMod{}
+
+	if name := strings.TrimSpace(filter.Name); name != "" {
+		mods = append(mods, qm.Where(M.ProductTableColumns.Name+" ILIKE ?", "%"+escapeLike(name)+"%"))
+	}
+
+	if filter.MinPrice != nil {
+		mods = append(mods, qm.Where(M.ProductTableColumns.Price+" >= ?", *filter.MinPrice))
+	}
+
+	if filter.MaxPrice != nil {
+		mods = append(mods, qm.Where(M.ProductTableColumns.Price+" <= ?", *filter.MaxPrice))
+	}
+
+	return mods
+}
+
+var productSortColumns = map[string]string{
+	M.ProductColumns.ID:    M.ProductTableColumns.ID,
+	M.ProductColumns.Name:  M.ProductTableColumns.Name,
+	M.ProductColumns.Price: M.ProductTableColumns.Price,
+}
+
+// productOrderBy validates sort and builds the ORDER BY for it, falling back to
+// fallback when sort is empty. id is always appended in the same direction as a
+// tiebreaker so rows with equal sort values keep a stable order across pages.
+func productOrderBy(sort, fallback string) (qm.QueryMod, *T.ServiceError) {
+	if sort = strings.TrimSpace(sort); sort == "" {
+		sort = fallback
+	}
+
+	direction := "ASC"
+	if strings.HasPrefix(sort, "-") {
+		direction = "DESC"
+		sort = sort[1:]
+	}
+
+	if sort == naturalNameSort {
+		return qm.OrderBy(naturalNameOrder(direction)), nil
+	}
+
+	column, ok := productSortColumns[sort]
+	if !ok {
+		return nil, &T.ServiceError{
+			Message:     "Invalid sort " + sort,
+			MessageCode: T.MsgSortInvalid,
+			Error:       errors.New("unknown sort column"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	if column == M.ProductTableColumns.ID {
+		return qm.OrderBy(column + " " + direction), nil
+	}
+
+	return qm.OrderBy(column + " " + direction + ", " + M.ProductTableColumns.ID + " " + direction), nil
+}
+
+// seededOrderBy orders by md5(id || seed), a pseudo-random order that is stable
+// for a seed. No index can serve it, so every page hashes and sorts all matching
+// rows: fine for thousands of products, too slow for millions without a
+// precomputed shuffle column.
+func seededOrderBy(seed string) qm.QueryMod {
+	return qm.OrderBy("md5("+M.ProductTableColumns.ID+"::text || ?), "+M.ProductTableColumns.ID, seed)
+}
+
+// naturalNameSort orders names the way people read them, so "Item 2" comes
+// before "Item 10". Plain "name" stays lexical.
+const naturalNameSort = "name_natural"
+
+// naturalNameOrder compares the text before the first number, then that number
+// numerically, then the whole name. Names that differ only in a later number,
+// like "Set 1 Part 9" and "Set 1 Part 10", fall back to lexical order. No index
+// serves it, so it is meant for the bounded lists merchandisers page through.
+func naturalNameOrder(direction string) string {
+	name := M.ProductTableColumns.Name
+	return "lower(substring(" + name + " from '^\\D*')) " + direction +
+		", substring(" + name + " from '\\d+')::numeric " + direction + " NULLS FIRST" +
+		", lower(" + name + ") " + direction +
+		", " + M.ProductTableColumns.ID + " " + direction
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/aarondl/sqlboiler/v4/types"
+	C "github.com/atharvbhadange/go-api-template/constants"
+)
+
+type numberFormat struct {
+	group   string
+	decimal string
+	// Digits per group left of the first group of three. 0 means 3; Indian
+	// grouping uses 2, as in 12,34,567.
+	secondaryGroupSize int
+}
+
+// Keyed by language or language-REGION, both lowercase.
+var localeNumberFormats = map[string]numberFormat{
+	"en":    {group: ",", decimal: "."},
+	"en-in": {group: ",", decimal: ".", secondaryGroupSize: 2},
+	"de":    {group: ".", decimal: ","},
+	"de-ch": {group: "'", decimal: "."},
+	"es":    {group: ".", decimal: ","},
+	"es-mx": {group: ",", decimal: "."},
+	"fr":    {group: " ", decimal: ","},
+	"fr-ch": {group: " ", decimal: "."},
+	"it":    {group: ".", decimal: ","},
+	"ja":    {group: ",", decimal: "."},
+	"nl":    {group: ".", decimal: ","},
+	"pl":    {group: " ", decimal: ","},
+	"pt":    {group: ".", decimal: ","},
+	"ru":    {group: " ", decimal: ","},
+	"sv":    {group: " ", decimal: ","},
+	"zh":    {group: ",", decimal: "."},
+}
+
+// FormatPriceForLocale formats d with the grouping and decimal separators of the
+// BCP-47 language tag, falling back to the default locale for unknown tags.
+func FormatPriceForLocale(d types.Decimal, currency, langTag string) string {
+	format := lookupNumberFormat(langTag)
+
+	if normalized, serviceErr := NormalizeCurrency(currency); serviceErr == nil {
+		currency = normalized
+	}
+
+	value := Price{d}.Amount()
+
+	fixed := value.Abs().StringFixed(CurrencyScale(currency))
+	intPart, fracPart, _ := strings.Cut(fixed, ".")
+
+	var b strings.Builder
+	if value.IsNegative() {
+		b.WriteString("-")
+	}
+	for i, digit := range intPart {
+		if i > 0 && format.groupsBefore(len(intPart)-i) {
+			b.WriteString(format.group)
+		}
+		b.WriteRune(digit)
+	}
+	if fracPart != "" {
+		b.WriteString(format.decimal)
+		b.WriteString(fracPart)
+	}
+
+	return b.String() + " " + strings.ToUpper(currency)
+}
+
+// groupsBefore reports whether a separator precedes a digit when remaining
+// digits, that one included, are still to be written.
+func (format numberFormat) groupsBefore(remaining int) bool {
+	secondary := format.secondaryGroupSize
+	if secondary == 0 {
+		secondary = 3
+	}
+	return remaining >= 3 && (remaining-3)%secondary == 0
+}
+
+func lookupNumberFormat(langTag string) numberFormat {
+	language, region := parseLanguageTag(langTag)
+
+	if format, ok := localeNumberFormats[language+"-"+region]; ok && region != "" {
+		return format
+	}
+	if format, ok := localeNumberFormats[language]; ok {
+		return format
+	}
+	return localeNumberFormats[C.DEFAULT_LOCALE]
+}
+
+// parseLanguageTag extracts the primary language and region subtags from a BCP-47
+// tag such as "pt-BR" or "zh-Hant-TW", skipping any script subtag.
+func parseLanguageTag(tag string) (string, string) {
+	subtags := strings.FieldsFunc(strings.ToLower(strings.TrimSpace(tag)), func(r rune) bool {
+		return r == '-' || r == '_'
+	})
+
+	if len(subtags) == 0 {
+		return "", ""
+	}
+
+	for _, subtag := range subtags[1:] {
+		if len(subtag) == 2 || len(subtag) == 3 && strings.Trim(subtag, "0123456789") == "" {
+			return subtags[0], subtag
+		}
+	}
+
+	return subtags[0], ""
+}
@@ -0,0 +1,38 @@
+package services_test
+
+import (
+	"testing"
+
+	S "github.com/atharvbhadange/go-api-template/api/v1/services"
+	"github.com/shopspring/decimal"
+)
+
+func TestFormatPriceForLocale(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		currency string
+		langTag  string
+		want     string
+	}{
+		{name: "western grouping", amount: "1234567.89", currency: "USD", langTag: "en-US", want: "1,234,567.89 USD"},
+		{name: "indian lakh grouping", amount: "1234567.89", currency: "INR", langTag: "en-IN", want: "12,34,567.89 INR"},
+		{name: "indian crore", amount: "123456789", currency: "INR", langTag: "en-IN", want: "12,34,56,789.00 INR"},
+		{name: "indian thousands", amount: "12345", currency: "INR", langTag: "en-IN", want: "12,345.00 INR"},
+		{name: "indian under a thousand", amount: "999", currency: "INR", langTag: "en-IN", want: "999.00 INR"},
+		{name: "indian negative", amount: "-1234567", currency: "INR", langTag: "en-IN", want: "-12,34,567.00 INR"},
+		{name: "german separators", amount: "1234567.5", currency: "EUR", langTag: "de-DE", want: "1.234.567,50 EUR"},
+		{name: "swiss region overrides language", amount: "1234.5", currency: "CHF", langTag: "de-CH", want: "1'234.50 CHF"},
+		{name: "unknown tag falls back", amount: "1234.5", currency: "USD", langTag: "xx", want: "1,234.50 USD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price := S.PriceFromDecimal(decimal.RequireFromString(tt.amount)).Model()
+
+			if got := S.FormatPriceForLocale(price, tt.currency, tt.langTag); got != tt.want {
+				t.Fatalf("FormatPriceForLocale(%s, %s, %s) = %q, want %q", tt.amount, tt.currency, tt.langTag, got, tt.want)
+			}
+		})
+	}
+}
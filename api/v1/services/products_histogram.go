@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/aarondl/sqlboiler/v4/types"
+	M "github.com/atharvbhadange/go-api-template/models"
+	T "github.com/atharvbhadange/go-api-template/types"
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+)
+
+// PriceBucket counts the products priced in [BucketStart, BucketStart+bucketSize).
+type PriceBucket struct {
+	BucketStart types.Decimal `boil:"bucket_start" json:"bucket_start"`
+	Count       int64         `boil:"count" json:"count"`
+}
+
+// GetPriceHistogram groups products into fixed-width price buckets in a single
+// GROUP BY query, lowest bucket first. Empty buckets are not returned. Buckets
+// are aligned to multiples of bucketSize, which width_bucket cannot do without
+// first querying the price range.
+func (s *ProductService) GetPriceHistogram(dbTrx boil.ContextExecutor, ctx context.Context, bucketSize decimal.Decimal) ([]*PriceBucket, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if !bucketSize.IsPositive() {
+		return nil, &T.ServiceError{
+			Message:     "Bucket size must be greater than 0",
+			MessageCode: T.MsgBucketSizeInvalid,
+			Error:       errors.New("invalid bucket size"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	query := fmt.Sprintf(
+		"SELECT floor(%[1]s / $1) * $1 AS bucket_start, count(*) AS count FROM %[2]s GROUP BY 1 ORDER BY 1",
+		M.ProductColumns.Price,
+		M.TableNames.Products,
+	)
+
+	buckets := []*PriceBucket{}
+
+	if err := queries.Raw(query, bucketSize).Bind(ctx, dbTrx, &buckets); err != nil {
+		return nil, &T.ServiceError{
+			Message: "Unable to get price histogram",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	return buckets, nil
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+
+	M "github.com/atharvbhadange/go-api-template/models"
+)
+
+// ProductHook is a side effect run after a product write has been committed,
+// such as indexing the product or warming a cache.
+type ProductHook func(ctx context.Context, product *M.Product) error
+
+// PriceWatchHook delivers the price watches an update of product met, for
+// example by sending the watching users a notification.
+type PriceWatchHook func(ctx context.Context, product *M.Product, watches []*PriceWatch) error
+
+// RunProductCreatedHooks runs the OnProductCreated hooks in order. Call it only
+// after the create has been committed. A failing hook is logged and the
+// remaining hooks still run; the create itself stands.
+func (s *ProductService) RunProductCreatedHooks(ctx context.Context, product *M.Product) {
+	s.runHooks(ctx, "created", s.Config.OnProductCreated, product)
+}
+
+// RunProductUpdatedHooks is RunProductCreatedHooks for OnProductUpdated.
+func (s *ProductService) RunProductUpdatedHooks(ctx context.Context, product *M.Product) {
+	s.runHooks(ctx, "updated", s.Config.OnProductUpdated, product)
+}
+
+// RunProductDeletedHooks is RunProductCreatedHooks for OnProductDeleted. The
+// row is gone, so only the product's ID is guaranteed to be set.
+func (s *ProductService) RunProductDeletedHooks(ctx context.Context, product *M.Product) {
+	s.runHooks(ctx, "deleted", s.Config.OnProductDeleted, product)
+}
+
+func (s *ProductService) runHooks(ctx context.Context, event string, hooks []ProductHook, product *M.Product) {
+	for i, hook := range hooks {
+		if err := hook(ctx, product); err != nil {
+			s.logger().Printf("product %s hook %d failed for product %d: %v", event, i, product.ID, err)
+		}
+	}
+}
+
+// RunPriceWatchHooks runs the OnPriceWatchesTriggered hooks in order, after the
+// update that met the watches has been committed. With no watches it does
+// nothing. Failures are logged like the product hooks'; the watches stay marked
+// notified.
+func (s *ProductService) RunPriceWatchHooks(ctx context.Context, product *M.Product, watches []*PriceWatch) {
+	if len(watches) == 0 {
+		return
+	}
+
+	for i, hook := range s.Config.OnPriceWatchesTriggered {
+		if err := hook(ctx, product, watches); err != nil {
+			s.logger().Printf("price watch hook %d failed for product %d: %v", i, product.ID, err)
+		}
+	}
+}
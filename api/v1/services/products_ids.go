@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	C "github.com/atharvbhadange/go-api-template/constants"
+	M "github.com/atharvbhadange/go-api-template/models"
+	T "github.com/atharvbhadange/go-api-template/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ParseProductIDs parses a comma-separated id list such as the ids query
+// parameter. Entries are trimmed and empty ones skipped, so "1, 2," is [1 2].
+// Duplicates are dropped keeping the first occurrence. A non-numeric or
+// non-positive entry is rejected with its 1-based position, and more than
+// C.PRODUCT_IDS_MAX distinct ids is rejected.
+func ParseProductIDs(raw string) ([]int, *T.ServiceError) {
+	ids := []int{}
+	seen := map[int]bool{}
+
+	for i, entry := range strings.Split(raw, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+
+		id, err := strconv.Atoi(entry)
+		if err != nil || id <= 0 {
+			if err == nil {
+				err = errors.New("product id must be positive")
+			}
+			return nil, &T.ServiceError{
+				Message:     fmt.Sprintf("Invalid product id %q at position %d", entry, i+1),
+				MessageCode: T.MsgProductIDInvalid,
+				Error:       err,
+				Code:        fiber.StatusBadRequest,
+			}
+		}
+
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		if len(ids) == C.PRODUCT_IDS_MAX {
+			return nil, &T.ServiceError{
+				Message:     fmt.Sprintf("Cannot request more than %d product ids", C.PRODUCT_IDS_MAX),
+				MessageCode: T.MsgTooManyIDs,
+				Error:       errors.New("too many product ids"),
+				Code:        fiber.StatusBadRequest,
+			}
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// GetProductsByIDString is GetProductsByIDs for a raw comma-separated id list,
+// parsed with ParseProductIDs.
+func (s *ProductService) GetProductsByIDString(dbTrx boil.ContextExecutor, ctx context.Context, raw string) ([]*M.Product, *T.ServiceError) {
+	ids, serviceErr := ParseProductIDs(raw)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	return s.GetProductsByIDs(dbTrx, ctx, ids)
+}
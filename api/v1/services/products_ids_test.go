@@ -0,0 +1,69 @@
+package services_test
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	S "github.com/atharvbhadange/go-api-template/api/v1/services"
+	"github.com/atharvbhadange/go-api-template/api/v1/services/testutil"
+	C "github.com/atharvbhadange/go-api-template/constants"
+	T "github.com/atharvbhadange/go-api-template/types"
+)
+
+func TestParseProductIDs(t *testing.T) {
+	idList := func(from, to int) string {
+		entries := []string{}
+		for _, id := range testutil.IDRange(from, to) {
+			entries = append(entries, strconv.Itoa(id))
+		}
+		return strings.Join(entries, ",")
+	}
+
+	tests := []struct {
+		name        string
+		raw         string
+		want        []int
+		wantCode    string
+		wantMessage string
+	}{
+		{name: "empty", raw: "", want: []int{}},
+		{name: "single", raw: "7", want: []int{7}},
+		{name: "trimmed", raw: " 1 ,\t2 , 3 ", want: []int{1, 2, 3}},
+		{name: "empty entries skipped", raw: "1,,2,", want: []int{1, 2}},
+		{name: "only separators", raw: " , ,", want: []int{}},
+		{name: "duplicates keep the first", raw: "3,1,3,2,1", want: []int{3, 1, 2}},
+		{name: "non-numeric first", raw: "x,2", wantCode: T.MsgProductIDInvalid, wantMessage: `Invalid product id "x" at position 1`},
+		{name: "position counts raw entries", raw: "1,,abc", wantCode: T.MsgProductIDInvalid, wantMessage: `Invalid product id "abc" at position 3`},
+		{name: "position after trimming", raw: "1,  2.5 ", wantCode: T.MsgProductIDInvalid, wantMessage: `Invalid product id "2.5" at position 2`},
+		{name: "zero", raw: "1,0", wantCode: T.MsgProductIDInvalid, wantMessage: `Invalid product id "0" at position 2`},
+		{name: "negative", raw: "-4", wantCode: T.MsgProductIDInvalid, wantMessage: `Invalid product id "-4" at position 1`},
+		{name: "at the limit", raw: idList(1, C.PRODUCT_IDS_MAX), want: testutil.IDRange(1, C.PRODUCT_IDS_MAX)},
+		{name: "duplicates do not count toward the limit", raw: idList(1, C.PRODUCT_IDS_MAX) + ",1", want: testutil.IDRange(1, C.PRODUCT_IDS_MAX)},
+		{name: "over the limit", raw: idList(1, C.PRODUCT_IDS_MAX+1), wantCode: T.MsgTooManyIDs},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, serviceErr := S.ParseProductIDs(tt.raw)
+
+			if tt.wantCode != "" {
+				if serviceErr == nil || serviceErr.MessageCode != tt.wantCode {
+					t.Fatalf("ParseProductIDs(%q) = %v, %+v; want %s", tt.raw, got, serviceErr, tt.wantCode)
+				}
+				if tt.wantMessage != "" && serviceErr.Message != tt.wantMessage {
+					t.Fatalf("ParseProductIDs(%q) message = %q, want %q", tt.raw, serviceErr.Message, tt.wantMessage)
+				}
+				return
+			}
+
+			if serviceErr != nil {
+				t.Fatalf("ParseProductIDs(%q) = %q: %v", tt.raw, serviceErr.Message, serviceErr.Error)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ParseProductIDs(%q) = %v, want %v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
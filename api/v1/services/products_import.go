@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	C "github.com/atharvbhadange/go-api-template/constants"
+	M "github.com/atharvbhadange/go-api-template/models"
+	T "github.com/atharvbhadange/go-api-template/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ImportItemError struct {
+	Index   int    `json:"index"`
+	Message string `json:"message"`
+}
+
+type ImportResult struct {
+	Processed int               `json:"processed"`
+	Inserted  int               `json:"inserted"`
+	Errors    []ImportItemError `json:"errors"`
+}
+
+// ImportProductsJSONStream reads a JSON array of products from r one element at a
+// time and inserts the valid ones in batches of IMPORT_BATCH_SIZE, so memory use
+// does not grow with the size of the input. Invalid items are reported in the
+// result and skipped. progress, when set, is called after every flushed batch.
+func (s *ProductService) ImportProductsJSONStream(dbTrx boil.ContextExecutor, ctx context.Context, r io.Reader, progress func(ImportResult)) (*ImportResult, *T.ServiceError) {
+	if serviceErr := checkMaintenance(); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	decoder := json.NewDecoder(r)
+	result := &ImportResult{Errors: []ImportItemError{}}
+
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		if err == nil {
+			err = errors.New("expected a JSON array")
+		}
+		return nil, &T.ServiceError{
+			Message:     "Invalid import document",
+			MessageCode: T.MsgImportDocumentInvalid,
+			Error:       err,
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	batch := make([]*ProductBody, 0, C.IMPORT_BATCH_SIZE)
+
+	flush := func() *T.ServiceError {
+		if len(batch) == 0 {
+			return nil
+		}
+		products, err := s.insertProductBatch(dbTrx, ctx, batch)
+		if err != nil {
+			return &T.ServiceError{
+				Message: "Unable to import products",
+				Error:   err,
+				Code:    dbErrorStatus(err),
+			}
+		}
+		records := make([]AuditRecord, len(products))
+		for i, product := range products {
+			records[i] = AuditRecord{Action: AuditActionCreate, ProductID: product.ID, After: product}
+		}
+		if serviceErr := s.auditMany(ctx, dbTrx, records); serviceErr != nil {
+			return serviceErr
+		}
+		result.Inserted += len(products)
+		batch = batch[:0]
+		if progress != nil {
+			progress(*result)
+		}
+		return nil
+	}
+
+	for index := 0; decoder.More(); index++ {
+		body := &ProductBody{}
+		err := decoder.Decode(body)
+		result.Processed++
+
+		// Syntax errors leave the decoder unable to find the next item, anything
+		// else (wrong types, a bad price) only affects the current one.
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, &T.ServiceError{
+				Message:     fmt.Sprintf("Invalid import document at item %d", index),
+				MessageCode: T.MsgImportDocumentInvalid,
+				Error:       err,
+				Code:        fiber.StatusBadRequest,
+			}
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, ImportItemError{Index: index, Message: err.Error()})
+			continue
+		}
+
+		body = s.prepareBody(body)
+
+		if serviceErr := body.Validate(); serviceErr != nil {
+			result.Errors = append(result.Errors, ImportItemError{Index: index, Message: serviceErr.Message})
+			continue
+		}
+
+		if serviceErr := s.checkBodyConfig(body); serviceErr != nil {
+			result.Errors = append(result.Errors, ImportItemError{Index: index, Message: serviceErr.Message})
+			continue
+		}
+
+		if serviceErr := s.runValidators(ctx, body); serviceErr != nil {
+			result.Errors = append(result.Errors, ImportItemError{Index: index, Message: serviceErr.Message})
+			continue
+		}
+
+		batch = append(batch, body)
+
+		if len(batch) == C.IMPORT_BATCH_SIZE {
+			if serviceErr := flush(); serviceErr != nil {
+				return nil, serviceErr
+			}
+		}
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, &T.ServiceError{
+			Message:     "Invalid import document",
+			MessageCode: T.MsgImportDocumentInvalid,
+			Error:       err,
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	if serviceErr := flush(); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	return result, nil
+}
+
+// ImportConflict reports an incoming row that matches an existing product.
+type ImportConflict struct {
+	Index      int    `json:"index"`
+	Key        string `json:"key"`
+	ExistingID int    `json:"existing_id"`
+	Existing   string `json:"existing_name"`
+}
+
+// PreviewImportConflicts lists the bodies whose name already belongs to a product,
+// compared case-insensitively after trimming, without inserting anything. Name is
+// the only natural key products have; rows are looked up IMPORT_BATCH_SIZE names
+// at a time.
+func (s *ProductService) PreviewImportConflicts(dbTrx boil.ContextExecutor, ctx context.Context, bodies []*ProductBody) ([]ImportConflict, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	names := []interface{}{}
+	seen := map[string]bool{}
+	for _, body := range bodies {
+		if body == nil {
+			continue
+		}
+		key := importConflictKey(body.Name)
+		if key != "" && !seen[key] {
+			seen[key] = true
+			names = append(names, key)
+		}
+	}
+
+	existing := map[string]*M.Product{}
+
+	for start := 0; start < len(names); start += C.IMPORT_BATCH_SIZE {
+		end := min(start+C.IMPORT_BATCH_SIZE, len(names))
+
+		products, err := M.Products(
+			qm.WhereIn("lower(trim("+M.ProductTableColumns.Name+")) IN ?", names[start:end]...),
+			qm.OrderBy(M.ProductColumns.ID),
+		).All(ctx, dbTrx)
+		if err != nil {
+			return nil, &T.ServiceError{
+				Message: "Unable to check import conflicts",
+				Error:   err,
+				Code:    dbErrorStatus(err),
+			}
+		}
+
+		for _, product := range products {
+			key := importConflictKey(product.Name)
+			if _, ok := existing[key]; !ok {
+				existing[key] = product
+			}
+		}
+	}
+
+	conflicts := []ImportConflict{}
+	for index, body := range bodies {
+		if body == nil {
+			continue
+		}
+		if product, ok := existing[importConflictKey(body.Name)]; ok {
+			conflicts = append(conflicts, ImportConflict{
+				Index:      index,
+				Key:        M.ProductColumns.Name,
+				ExistingID: product.ID,
+				Existing:   product.Name,
+			})
+		}
+	}
+
+	return conflicts, nil
+}
+
+func importConflictKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// insertProductBatch inserts all bodies with a single multi-row INSERT and
+// returns the inserted rows.
+func (s *ProductService) insertProductBatch(dbTrx boil.ContextExecutor, ctx context.Context, bodies []*ProductBody) ([]*M.Product, error) {
+	placeholders := make([]string, 0, len(bodies))
+	args := make([]interface{}, 0, len(bodies)*3)
+
+	for i, body := range bodies {
+		placeholders = append(placeholders, fmt.Sprintf("($%d, $%d, $%d)", i*3+1, i*3+2, i*3+3))
+		args = append(args,
+			body.Name,
+			s.descriptionValue(body.Description),
+			body.Price.Model(),
+		)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s, %s, %s) VALUES %s RETURNING *",
+		M.TableNames.Products,
+		M.ProductColumns.Name,
+		M.ProductColumns.Description,
+		M.ProductColumns.Price,
+		strings.Join(placeholders, ", "),
+	)
+
+	products := []*M.Product{}
+
+	if err := queries.Raw(query, args...).Bind(ctx, dbTrx, &products); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
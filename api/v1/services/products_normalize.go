@@ -0,0 +1,60 @@
+package services
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// prepareBody returns a copy of body with the configured input normalization
+// applied, leaving the caller's body untouched.
+func (s *ProductService) prepareBody(body *ProductBody) *ProductBody {
+	prepared := *body
+
+	if !s.Config.PreserveWhitespace {
+		prepared.Name = strings.TrimSpace(prepared.Name)
+		prepared.Description = strings.TrimSpace(prepared.Description)
+	}
+
+	if s.Config.NormalizeNames {
+		prepared.Name = normalizeName(prepared.Name, s.Config.TitleCaseNames)
+	}
+
+	return &prepared
+}
+
+// trimPatchedString applies the auto-trim to a string set by a merge patch.
+func (s *ProductService) trimPatchedString(value string) string {
+	if s.Config.PreserveWhitespace {
+		return value
+	}
+	return strings.TrimSpace(value)
+}
+
+// preparePatchedName applies prepareBody's name handling to a name set by a
+// merge patch.
+func (s *ProductService) preparePatchedName(name string) string {
+	name = s.trimPatchedString(name)
+
+	if s.Config.NormalizeNames {
+		name = normalizeName(name, s.Config.TitleCaseNames)
+	}
+
+	return name
+}
+
+// normalizeName trims the name, collapses inner runs of whitespace to a single
+// space and, with titleCase, upper-cases the first letter of every word and
+// lower-cases the rest.
+func normalizeName(name string, titleCase bool) string {
+	words := strings.Fields(name)
+
+	if titleCase {
+		for i, word := range words {
+			first, size := utf8.DecodeRuneInString(word)
+			words[i] = string(unicode.ToUpper(first)) + strings.ToLower(word[size:])
+		}
+	}
+
+	return strings.Join(words, " ")
+}
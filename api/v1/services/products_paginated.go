@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	M "github.com/atharvbhadange/go-api-template/models"
+	T "github.com/atharvbhadange/go-api-template/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetProductsPaginated returns one page of the products matching filter, plus the
+// total number of matches. The count uses the same WHERE clauses as the page query.
+func (s *ProductService) GetProductsPaginated(dbTrx boil.ContextExecutor, ctx context.Context, filter ProductFilter) (*T.Page[*M.Product], *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	page, pageSize, serviceErr := s.pageBounds(filter)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	var orderBy qm.QueryMod
+	if filter.Seed != "" {
+		if filter.Sort != "" {
+			return nil, &T.ServiceError{
+				Message:     "Sort and seed cannot be combined",
+				MessageCode: T.MsgSortInvalid,
+				Error:       errors.New("invalid ordering"),
+				Code:        fiber.StatusBadRequest,
+			}
+		}
+		orderBy = seededOrderBy(filter.Seed)
+	} else {
+		orderBy, serviceErr = productOrderBy(filter.Sort, M.ProductColumns.ID)
+		if serviceErr != nil {
+			return nil, serviceErr
+		}
+	}
+
+	where := filter.queryMods()
+
+	total, approximate, err := s.countProducts(dbTrx, ctx, filter, where)
+	if err != nil {
+		return nil, &T.ServiceError{
+			Message: "Unable to count products",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	mods := append(where,
+		orderBy,
+		qm.Limit(pageSize),
+		qm.Offset((page-1)*pageSize),
+	)
+
+	products, err := M.Products(mods...).All(ctx, dbTrx)
+	if err != nil {
+		return nil, &T.ServiceError{
+			Message: "Unable to get products",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	if products == nil {
+		products = M.ProductSlice{}
+	}
+
+	return &T.Page[*M.Product]{
+		Items:    products,
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+
+		TotalApproximate: approximate,
+	}, nil
+}
+
+// countProducts counts the filtered rows exactly. Unfiltered lists on a table the
+// planner estimates at ApproximateCountMinRows or more use that estimate
+// (pg_class.reltuples, refreshed by ANALYZE and autovacuum) instead, since an
+// exact count(*) has to scan the whole table.
+func (s *ProductService) countProducts(dbTrx boil.ContextExecutor, ctx context.Context, filter ProductFilter, where []qm.QueryMod) (int64, bool, error) {
+	if s.Config.ApproximateCountMinRows > 0 && filter.IsEmpty() {
+		var estimate struct {
+			Rows float64 `boil:"reltuples"`
+		}
+
+		err := queries.Raw("SELECT reltuples FROM pg_class WHERE oid = to_regclass($1)", M.TableNames.Products).Bind(ctx, dbTrx, &estimate)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return 0, false, err
+		}
+
+		// reltuples is -1 until the table has been analyzed.
+		if err == nil && estimate.Rows >= float64(s.Config.ApproximateCountMinRows) {
+			return int64(estimate.Rows), true, nil
+		}
+	}
+
+	total, err := M.Products(where...).Count(ctx, dbTrx)
+	return total, false, err
+}
+
+func (s *ProductService) pageBounds(filter ProductFilter) (int, int, *T.ServiceError) {
+	page, pageSize := filter.Page, filter.PageSize
+
+	if page < 0 || pageSize < 0 {
+		return 0, 0, &T.ServiceError{
+			Message:     "Page and page size cannot be negative",
+			MessageCode: T.MsgPaginationInvalid,
+			Error:       errors.New("invalid pagination"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	if pageSize > s.maxPageSize() {
+		return 0, 0, &T.ServiceError{
+			Message:     fmt.Sprintf("Page size cannot be larger than %d", s.maxPageSize()),
+			MessageCode: T.MsgPaginationInvalid,
+			Error:       errors.New("invalid pagination"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	if page == 0 {
+		page = 1
+	}
+
+	if pageSize == 0 {
+		pageSize = s.defaultPageSize()
+	}
+
+	return page, pageSize, nil
+}
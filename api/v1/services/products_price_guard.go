@@ -0,0 +1,35 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aarondl/sqlboiler/v4/types"
+	T "github.com/atharvbhadange/go-api-template/types"
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+)
+
+func (s *ProductService) checkPriceChange(current types.Decimal, next decimal.Decimal, force bool) *T.ServiceError {
+	if force || s.Config.PriceChangeGuardPercent <= 0 || current.Big == nil {
+		return nil
+	}
+
+	old := Price{current}.Amount()
+	if old.IsZero() {
+		return nil
+	}
+
+	changePercent := next.Sub(old).Abs().Div(old).Mul(decimal.NewFromInt(100))
+
+	if changePercent.GreaterThan(decimal.NewFromInt(int64(s.Config.PriceChangeGuardPercent))) {
+		return &T.ServiceError{
+			Message:     fmt.Sprintf("Price change from %s to %s exceeds %d%%, retry with force to apply it", old, next, s.Config.PriceChangeGuardPercent),
+			MessageCode: T.MsgProductPriceChangeTooLarge,
+			Error:       errors.New("price change too large"),
+			Code:        fiber.StatusUnprocessableEntity,
+		}
+	}
+
+	return nil
+}
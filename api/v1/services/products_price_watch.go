@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries"
+	"github.com/aarondl/sqlboiler/v4/types"
+	T "github.com/atharvbhadange/go-api-template/types"
+	"github.com/shopspring/decimal"
+)
+
+// PriceWatch is one row of price_watches: a user waiting for a product's price
+// to drop to TargetPrice or below. NotifiedAt is set once the watch has fired.
+type PriceWatch struct {
+	ID          int           `boil:"id" json:"id"`
+	ProductID   int           `boil:"product_id" json:"product_id"`
+	UserID      string        `boil:"user_id" json:"user_id"`
+	TargetPrice types.Decimal `boil:"target_price" json:"target_price"`
+	CreatedAt   time.Time     `boil:"created_at" json:"created_at"`
+	NotifiedAt  null.Time     `boil:"notified_at" json:"notified_at"`
+}
+
+// CheckPriceWatches returns the pending watches on the product whose target
+// newPrice meets and marks them notified in the same statement, so each watch
+// fires once and only if the caller's transaction commits. Delivering the
+// notifications is up to the caller, after that commit.
+func (s *ProductService) CheckPriceWatches(dbTrx boil.ContextExecutor, ctx context.Context, productID int, newPrice decimal.Decimal) ([]*PriceWatch, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if serviceErr := validateProductID(productID); serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	watches := []*PriceWatch{}
+
+	err := queries.Raw(
+		"UPDATE price_watches SET notified_at = CURRENT_TIMESTAMP WHERE product_id = $1 AND notified_at IS NULL AND target_price >= $2 RETURNING id, product_id, user_id, target_price, created_at, notified_at",
+		productID, newPrice,
+	).Bind(ctx, dbTrx, &watches)
+	if err != nil {
+		return nil, &T.ServiceError{
+			Message: "Unable to check price watches",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	return watches, nil
+}
+
+// checkPriceWatchesOnChange runs CheckPriceWatches when an update moved the
+// price, so writes that leave it alone cost no extra statement. It does nothing
+// unless Config.PriceWatches is on and an OnPriceWatchesTriggered hook is set:
+// with no one to deliver them, marking watches notified would drop them.
+func (s *ProductService) checkPriceWatchesOnChange(dbTrx boil.ContextExecutor, ctx context.Context, productID int, before, after types.Decimal) ([]*PriceWatch, *T.ServiceError) {
+	if !s.Config.PriceWatches || len(s.Config.OnPriceWatchesTriggered) == 0 {
+		return []*PriceWatch{}, nil
+	}
+
+	previous, next := Price{before}.Amount(), Price{after}.Amount()
+
+	if before.Big != nil && previous.Equal(next) {
+		return []*PriceWatch{}, nil
+	}
+
+	return s.CheckPriceWatches(dbTrx, ctx, productID, next)
+}
@@ -0,0 +1,78 @@
+package services_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	S "github.com/atharvbhadange/go-api-template/api/v1/services"
+	"github.com/atharvbhadange/go-api-template/api/v1/services/testutil"
+	M "github.com/atharvbhadange/go-api-template/models"
+	"github.com/shopspring/decimal"
+)
+
+func TestUpdateProductPriceWatches(t *testing.T) {
+	hook := func(ctx context.Context, product *M.Product, watches []*S.PriceWatch) error { return nil }
+
+	tests := []struct {
+		name        string
+		enabled     bool
+		hooks       []S.PriceWatchHook
+		wantChecked bool
+	}{
+		{name: "disabled", enabled: false, hooks: []S.PriceWatchHook{hook}},
+		// Marking watches notified with no hook to deliver them would lose them.
+		{name: "enabled without a hook", enabled: true},
+		{name: "enabled with a hook", enabled: true, hooks: []S.PriceWatchHook{hook}, wantChecked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, err := S.NewProductService(S.ProductServiceConfig{
+				PriceWatches:            tt.enabled,
+				OnPriceWatchesTriggered: tt.hooks,
+			}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			fake := testutil.NewFakeExecutor()
+			fake.Push(
+				testutil.ProductsResult(1),
+				testutil.FakeResult{RowsAffected: 1},
+				testutil.FakeResult{RowsAffected: 1},
+				testutil.FakeResult{
+					Columns: []string{"id", "product_id", "user_id", "target_price", "created_at", "notified_at"},
+					Rows:    [][]driver.Value{{int64(7), int64(1), "user-1", "9.00", time.Now(), time.Now()}},
+				},
+			)
+
+			body := testutil.NewProductBody().WithPrice(decimal.RequireFromString("8.50")).Build()
+
+			_, _, watches, serviceErr := service.UpdateProduct(fake, context.Background(), 1, body, false)
+			if serviceErr != nil {
+				t.Fatalf("UpdateProduct() = %q: %v", serviceErr.Message, serviceErr.Error)
+			}
+
+			checked := false
+			for _, call := range fake.Calls() {
+				if strings.Contains(call.Query, "price_watches") {
+					checked = true
+				}
+			}
+			if checked != tt.wantChecked {
+				t.Fatalf("price_watches touched = %v, want %v", checked, tt.wantChecked)
+			}
+
+			wantWatches := 0
+			if tt.wantChecked {
+				wantWatches = 1
+			}
+			if len(watches) != wantWatches {
+				t.Fatalf("UpdateProduct() returned %d watches, want %d", len(watches), wantWatches)
+			}
+		})
+	}
+}
@@ -0,0 +1,384 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	C "github.com/atharvbhadange/go-api-template/constants"
+	M "github.com/atharvbhadange/go-api-template/models"
+	T "github.com/atharvbhadange/go-api-template/types"
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+)
+
+// EmptyNamePolicy decides what UpdateProduct and MergePatchProduct do with a
+// blank name, which is usually a client that left the field out of a full
+// update.
+type EmptyNamePolicy int
+
+const (
+	// Reject the update with 400, the same as on create.
+	EmptyNameReject EmptyNamePolicy = iota
+	// Keep the stored name and apply the other fields.
+	EmptyNameKeep
+	// Store the blank name.
+	EmptyNameAllow
+)
+
+// EmptyDescriptionPolicy decides how an empty description is stored, so every
+// write path renders it the same way.
+type EmptyDescriptionPolicy int
+
+const (
+	// Store NULL, which the JSON model omits.
+	EmptyDescriptionNull EmptyDescriptionPolicy = iota
+	// Store an empty string, rendered as "".
+	EmptyDescriptionString
+	// Reject the write with 400: every product needs a description.
+	EmptyDescriptionReject
+)
+
+type ProductServiceConfig struct {
+	// Largest price change, in percent of the current price, that UpdateProduct
+	// applies without force. 0 disables the guard.
+	PriceChangeGuardPercent int
+
+	// How many ids GetProductsByIDs puts in a single IN list. 0 uses the default.
+	IDsChunkSize int
+
+	// Whether a requested field that is not a product column fails the request
+	// or is dropped from the field set.
+	UnknownFieldMode UnknownFieldMode
+
+	// What UpdateProduct and MergePatchProduct do when the name is blank.
+	// Defaults to EmptyNameReject.
+	EmptyNameOnUpdate EmptyNamePolicy
+
+	// How creates and updates store an empty or null description. Defaults to
+	// EmptyDescriptionNull.
+	EmptyDescription EmptyDescriptionPolicy
+
+	// Deployment specific rules run by CreateProduct and UpdateProduct, in order.
+	Validators []ProductValidator
+
+	// Trim names and collapse inner whitespace on create and update, so "Widget"
+	// and " Widget  " are stored the same. With TitleCaseNames casing is folded
+	// too, which also merges "widget" and "WIDGET" but rewrites deliberate
+	// casing such as "iPhone" or "USB Cable"; only the normalized name is stored.
+	NormalizeNames bool
+	TitleCaseNames bool
+
+	// Keep leading and trailing whitespace in names and descriptions. By default
+	// creates and updates trim them before validation.
+	PreserveWhitespace bool
+
+	// When CreateProduct hits the unique name index, return the product that won
+	// the race instead of a 409 Conflict. Requires the caller's executor to be a
+	// transaction.
+	ReturnExistingOnConflict bool
+
+	// Accept bodies without a price and store them as free products. Off by
+	// default, so a price of 0 has to be sent explicitly.
+	AllowMissingPrice bool
+
+	// Longest description, in characters, that creates and updates accept. 0
+	// means no limit beyond the column's.
+	MaxDescriptionLength int
+
+	// Read-through cache used by GetProductsByIDsCached and invalidated by
+	// updates and deletes. nil disables caching.
+	Cache ProductCache
+
+	// Receives every create, update and delete. nil uses DBAuditLogger.
+	AuditLogger AuditLogger
+
+	// Write an event for every create, update and delete to the outbox table, in
+	// the write's transaction, for PollOutbox to hand to a broker. Off by default
+	// because it needs the table.
+	Outbox bool
+
+	// Side effects run after a create, update or delete has been committed, in
+	// order. The service cannot see the commit, so whoever commits runs them with
+	// RunProductCreatedHooks and its siblings; the controllers do. A hook error is
+	// logged and does not undo the write.
+	OnProductCreated []ProductHook
+	OnProductUpdated []ProductHook
+	OnProductDeleted []ProductHook
+
+	// Check the price_watches table when an update changes a price. Off by
+	// default because it needs the table, and skipped while no
+	// OnPriceWatchesTriggered hook is set, so watches stay pending.
+	PriceWatches bool
+
+	// Delivers the price watches an update met, run after commit with
+	// RunPriceWatchHooks like the product hooks above.
+	OnPriceWatchesTriggered []PriceWatchHook
+
+	// Largest number of rows the unbounded list functions return. Longer results
+	// are cut off and reported through ListMeta. 0 means no limit.
+	SoftResultLimit int
+
+	// Unfiltered paginated lists report the planner's row estimate instead of
+	// an exact count once the table is estimated to hold this many rows, and
+	// flag the total as approximate. 0 always counts exactly.
+	ApproximateCountMinRows int
+
+	// Page size used by the paginated functions when the filter sets none, and
+	// the largest one a caller may ask for. 0 uses the defaults.
+	DefaultPageSize int
+	MaxPageSize     int
+
+	// Deadline applied to the database work of each call. 0 uses the default.
+	// Streaming exports and imports are not bounded by it.
+	OperationTimeout time.Duration
+}
+
+// ProductService holds the dependencies of the product service functions.
+// Transactions stay per call, so a single service is shared across requests.
+type ProductService struct {
+	Config ProductServiceConfig
+	Logger *log.Logger
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// DefaultProductService backs the package-level functions.
+var DefaultProductService = &ProductService{
+	Config: ProductServiceConfig{
+		IDsChunkSize:     C.PRODUCT_IDS_CHUNK_SIZE,
+		OperationTimeout: C.DB_OPERATION_TIMEOUT,
+		DefaultPageSize:  C.DEFAULT_PAGE_SIZE,
+		MaxPageSize:      C.MAX_PAGE_SIZE,
+		AuditLogger:      DBAuditLogger{},
+	},
+	Logger: log.Default(),
+}
+
+func NewProductService(config ProductServiceConfig, logger *log.Logger) (*ProductService, error) {
+	if config.PriceChangeGuardPercent < 0 {
+		return nil, errors.New("price change guard percent cannot be negative")
+	}
+
+	if config.IDsChunkSize < 0 {
+		return nil, errors.New("ids chunk size cannot be negative")
+	}
+
+	if config.SoftResultLimit < 0 {
+		return nil, errors.New("soft result limit cannot be negative")
+	}
+
+	if config.ApproximateCountMinRows < 0 {
+		return nil, errors.New("approximate count min rows cannot be negative")
+	}
+
+	if config.MaxDescriptionLength < 0 {
+		return nil, errors.New("max description length cannot be negative")
+	}
+
+	if config.OperationTimeout < 0 {
+		return nil, errors.New("operation timeout cannot be negative")
+	}
+
+	if config.DefaultPageSize < 0 || config.MaxPageSize < 0 {
+		return nil, errors.New("page sizes cannot be negative")
+	}
+
+	if config.IDsChunkSize == 0 {
+		config.IDsChunkSize = C.PRODUCT_IDS_CHUNK_SIZE
+	}
+
+	if config.OperationTimeout == 0 {
+		config.OperationTimeout = C.DB_OPERATION_TIMEOUT
+	}
+
+	if config.DefaultPageSize == 0 {
+		config.DefaultPageSize = C.DEFAULT_PAGE_SIZE
+	}
+
+	if config.MaxPageSize == 0 {
+		config.MaxPageSize = C.MAX_PAGE_SIZE
+	}
+
+	if config.DefaultPageSize > config.MaxPageSize {
+		return nil, fmt.Errorf("default page size %d is larger than max page size %d", config.DefaultPageSize, config.MaxPageSize)
+	}
+
+	if config.AuditLogger == nil {
+		config.AuditLogger = DBAuditLogger{}
+	}
+
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return &ProductService{Config: config, Logger: logger}, nil
+}
+
+// Close releases what the service holds: the cache and the audit logger are
+// closed when they implement io.Closer. Call it once at shutdown, after the
+// server has stopped taking requests; later calls return the first result.
+func (s *ProductService) Close() error {
+	s.closeOnce.Do(func() {
+		for _, resource := range []interface{}{s.Config.Cache, s.Config.AuditLogger} {
+			closer, ok := resource.(io.Closer)
+			if !ok {
+				continue
+			}
+			if err := closer.Close(); err != nil {
+				s.closeErr = errors.Join(s.closeErr, err)
+			}
+		}
+	})
+	return s.closeErr
+}
+
+// withTimeout derives the context for one call's database work. Callers must
+// always call the returned cancel.
+func (s *ProductService) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, s.operationTimeout())
+}
+
+// The accessors below apply the "0 uses the default" rules when the config is
+// read, so a ProductService built as a literal instead of with
+// NewProductService behaves the same.
+
+func (s *ProductService) operationTimeout() time.Duration {
+	if s.Config.OperationTimeout <= 0 {
+		return C.DB_OPERATION_TIMEOUT
+	}
+	return s.Config.OperationTimeout
+}
+
+func (s *ProductService) idsChunkSize() int {
+	if s.Config.IDsChunkSize <= 0 {
+		return C.PRODUCT_IDS_CHUNK_SIZE
+	}
+	return s.Config.IDsChunkSize
+}
+
+func (s *ProductService) defaultPageSize() int {
+	if s.Config.DefaultPageSize <= 0 {
+		return C.DEFAULT_PAGE_SIZE
+	}
+	return s.Config.DefaultPageSize
+}
+
+func (s *ProductService) maxPageSize() int {
+	if s.Config.MaxPageSize <= 0 {
+		return C.MAX_PAGE_SIZE
+	}
+	return s.Config.MaxPageSize
+}
+
+func (s *ProductService) logger() *log.Logger {
+	if s.Logger == nil {
+		return log.Default()
+	}
+	return s.Logger
+}
+
+func (s *ProductService) auditLogger() AuditLogger {
+	if s.Config.AuditLogger == nil {
+		return DBAuditLogger{}
+	}
+	return s.Config.AuditLogger
+}
+
+// pqQueryCanceled is what lib/pq returns, instead of ctx.Err(), when the
+// deadline passes while a query is running and it cancels it on the server.
+// A server-side statement_timeout reports the same code.
+const pqQueryCanceled = "57014"
+
+// dbErrorStatus maps a failed database call to 504 when it ran out of time and
+// 500 otherwise.
+func dbErrorStatus(err error) int {
+	var pqErr *pq.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &pqErr) && pqErr.Code == pqQueryCanceled) {
+		return fiber.StatusGatewayTimeout
+	}
+	return fiber.StatusInternalServerError
+}
+
+// ListMeta describes an unbounded list result. When the soft result limit cut it
+// off, Truncated is set and Matched holds the full number of matching rows.
+type ListMeta struct {
+	Truncated bool  `json:"truncated"`
+	Matched   int64 `json:"matched"`
+}
+
+// softLimitMods fetches one row past the soft limit so truncation can be detected
+// without counting every time.
+func (s *ProductService) softLimitMods() []qm.QueryMod {
+	if s.Config.SoftResultLimit <= 0 {
+		return nil
+	}
+	return []qm.QueryMod{qm.Limit(s.Config.SoftResultLimit + 1)}
+}
+
+// applySoftLimit trims items to the soft limit and, only when that happened,
+// counts the real number of matches using the same where mods.
+func applySoftLimit[E any](s *ProductService, dbTrx boil.ContextExecutor, ctx context.Context, items []E, where []qm.QueryMod) ([]E, ListMeta, error) {
+	limit := s.Config.SoftResultLimit
+
+	if limit <= 0 || len(items) <= limit {
+		return items, ListMeta{Matched: int64(len(items))}, nil
+	}
+
+	matched, err := M.Products(where...).Count(ctx, dbTrx)
+	if err != nil {
+		return nil, ListMeta{}, err
+	}
+
+	return items[:limit], ListMeta{Truncated: true, Matched: matched}, nil
+}
+
+// auditMany records records through the logger's batch path when it has one,
+// and queues their outbox events.
+func (s *ProductService) auditMany(ctx context.Context, dbTrx boil.ContextExecutor, records []AuditRecord) *T.ServiceError {
+	if batch, ok := s.auditLogger().(BatchAuditLogger); ok {
+		if err := batch.RecordMany(ctx, dbTrx, records); err != nil {
+			return &T.ServiceError{
+				Message: "Unable to record audit log",
+				Error:   err,
+				Code:    dbErrorStatus(err),
+			}
+		}
+	} else {
+		for _, record := range records {
+			if serviceErr := s.recordAudit(ctx, dbTrx, record.Action, record.ProductID, record.Before, record.After); serviceErr != nil {
+				return serviceErr
+			}
+		}
+	}
+
+	return s.writeOutbox(ctx, dbTrx, records)
+}
+
+// audit records one write and queues its outbox event. Every write path goes
+// through it or auditMany, inside the write's transaction.
+func (s *ProductService) audit(ctx context.Context, dbTrx boil.ContextExecutor, action string, productID int, before, after *M.Product) *T.ServiceError {
+	if serviceErr := s.recordAudit(ctx, dbTrx, action, productID, before, after); serviceErr != nil {
+		return serviceErr
+	}
+
+	return s.writeOutbox(ctx, dbTrx, []AuditRecord{{Action: action, ProductID: productID, Before: before, After: after}})
+}
+
+func (s *ProductService) recordAudit(ctx context.Context, dbTrx boil.ContextExecutor, action string, productID int, before, after *M.Product) *T.ServiceError {
+	if err := s.auditLogger().Record(ctx, dbTrx, action, productID, before, after); err != nil {
+		return &T.ServiceError{
+			Message: "Unable to record audit log",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+	return nil
+}
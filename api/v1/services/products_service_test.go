@@ -0,0 +1,77 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	S "github.com/atharvbhadange/go-api-template/api/v1/services"
+	"github.com/atharvbhadange/go-api-template/api/v1/services/testutil"
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+)
+
+func TestDBErrorStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "deadline before the query", err: context.DeadlineExceeded, want: fiber.StatusGatewayTimeout},
+		{name: "wrapped deadline", err: fmt.Errorf("models: unable to select: %w", context.DeadlineExceeded), want: fiber.StatusGatewayTimeout},
+		{name: "query canceled on the server", err: &pq.Error{Code: "57014"}, want: fiber.StatusGatewayTimeout},
+		{name: "wrapped query canceled", err: fmt.Errorf("select: %w", &pq.Error{Code: "57014"}), want: fiber.StatusGatewayTimeout},
+		{name: "other pq error", err: &pq.Error{Code: "23505"}, want: fiber.StatusInternalServerError},
+		{name: "plain error", err: errors.New("connection refused"), want: fiber.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := S.DBErrorStatus(tt.err); got != tt.want {
+				t.Fatalf("dbErrorStatus(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// A zero-value service must use the documented defaults instead of a zero
+// timeout that fails every call.
+func TestZeroValueServiceUsesDefaults(t *testing.T) {
+	service := &S.ProductService{}
+
+	fake := testutil.NewFakeExecutor()
+	fake.Push(testutil.ProductsResult(1))
+
+	product, serviceErr := service.GetProduct(fake, context.Background(), 1)
+	if serviceErr != nil {
+		t.Fatalf("GetProduct() = %q: %v", serviceErr.Message, serviceErr.Error)
+	}
+	if product.ID != 1 {
+		t.Fatalf("GetProduct() returned product %d, want 1", product.ID)
+	}
+}
+
+func TestZeroChunkSizeUsesDefault(t *testing.T) {
+	service := &S.ProductService{}
+
+	fake := testutil.NewFakeExecutor()
+	fake.Push(testutil.ProductsResult(1, 2))
+
+	products, serviceErr := service.GetProductsByIDs(fake, context.Background(), []int{2, 1})
+	if serviceErr != nil {
+		t.Fatalf("GetProductsByIDs() = %q: %v", serviceErr.Message, serviceErr.Error)
+	}
+	if len(products) != 2 || products[0].ID != 2 || products[1].ID != 1 {
+		t.Fatalf("GetProductsByIDs() = %v, want products 2 and 1", products)
+	}
+	if calls := fake.Calls(); len(calls) != 1 {
+		t.Fatalf("GetProductsByIDs() ran %d statements, want 1", len(calls))
+	}
+
+	fake.Push(testutil.ProductsResult(1, 2))
+
+	if _, serviceErr := service.GetProductsByIDsSorted(fake, context.Background(), []int{1, 2}, "name"); serviceErr != nil {
+		t.Fatalf("GetProductsByIDsSorted() = %q, want the default chunk size to allow 2 ids", serviceErr.Message)
+	}
+}
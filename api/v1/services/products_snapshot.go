@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	C "github.com/atharvbhadange/go-api-template/constants"
+	M "github.com/atharvbhadange/go-api-template/models"
+	T "github.com/atharvbhadange/go-api-template/types"
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+)
+
+// ProductSnapshot is what a product looked like when an order was placed. It
+// holds only values, no pointers into the model, so orders can store it as is
+// and later product changes never show through.
+type ProductSnapshot struct {
+	ID         int             `json:"id"`
+	Name       string          `json:"name"`
+	Price      decimal.Decimal `json:"price"`
+	Currency   string          `json:"currency"`
+	CapturedAt time.Time       `json:"captured_at"`
+}
+
+// SnapshotProductForOrder captures the product for an order. The row is read
+// FOR SHARE, so inside the order's transaction the price cannot change between
+// the snapshot and the commit.
+func (s *ProductService) SnapshotProductForOrder(dbTrx boil.ContextExecutor, ctx context.Context, id int) (ProductSnapshot, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if serviceErr := validateProductID(id); serviceErr != nil {
+		return ProductSnapshot{}, serviceErr
+	}
+
+	product, err := M.Products(M.ProductWhere.ID.EQ(id), qm.For("SHARE")).One(ctx, dbTrx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ProductSnapshot{}, &T.ServiceError{
+				Message:     "Product not found",
+				MessageCode: T.MsgProductNotFound,
+				Error:       err,
+				Code:        fiber.StatusNotFound,
+			}
+		}
+		return ProductSnapshot{}, &T.ServiceError{
+			Message: "Unable to get product",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	return ProductSnapshot{
+		ID:         product.ID,
+		Name:       product.Name,
+		Price:      Price{product.Price}.Amount(),
+		Currency:   C.DEFAULT_CURRENCY,
+		CapturedAt: time.Now().UTC(),
+	}, nil
+}
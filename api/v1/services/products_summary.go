@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/aarondl/sqlboiler/v4/types"
+	M "github.com/atharvbhadange/go-api-template/models"
+	T "github.com/atharvbhadange/go-api-template/types"
+)
+
+type ProductSummary struct {
+	ID    int           `boil:"id" json:"id"`
+	Name  string        `boil:"name" json:"name"`
+	Price types.Decimal `boil:"price" json:"price"`
+}
+
+func (s *ProductService) GetProductSummaries(dbTrx boil.ContextExecutor, ctx context.Context, filter ProductFilter) ([]*ProductSummary, ListMeta, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	orderBy, serviceErr := productOrderBy(filter.Sort, M.ProductColumns.Name)
+	if serviceErr != nil {
+		return nil, ListMeta{}, serviceErr
+	}
+
+	where := filter.queryMods()
+
+	mods := append([]qm.QueryMod{
+		qm.Select(M.ProductTableColumns.ID, M.ProductTableColumns.Name, M.ProductTableColumns.Price),
+	}, where...)
+	mods = append(mods, orderBy)
+	mods = append(mods, s.softLimitMods()...)
+
+	summaries := []*ProductSummary{}
+
+	if err := M.Products(mods...).Bind(ctx, dbTrx, &summaries); err != nil {
+		return nil, ListMeta{}, &T.ServiceError{
+			Message: "Unable to get product summaries",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	summaries, meta, err := applySoftLimit(s, dbTrx, ctx, summaries, where)
+	if err != nil {
+		return nil, ListMeta{}, &T.ServiceError{
+			Message: "Unable to count product summaries",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	return summaries, meta, nil
+}
@@ -0,0 +1,143 @@
+package services_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/types"
+	S "github.com/atharvbhadange/go-api-template/api/v1/services"
+	"github.com/atharvbhadange/go-api-template/api/v1/services/testutil"
+	M "github.com/atharvbhadange/go-api-template/models"
+	"github.com/shopspring/decimal"
+)
+
+func TestDiffProducts(t *testing.T) {
+	price := func(s string) types.Decimal {
+		return S.PriceFromDecimal(decimal.RequireFromString(s)).Model()
+	}
+	product := func(name string, description null.String, amount string) *M.Product {
+		return &M.Product{ID: 1, Name: name, Description: description, Price: price(amount)}
+	}
+
+	tests := []struct {
+		name    string
+		old     *M.Product
+		updated *M.Product
+		want    map[string]S.FieldChange
+	}{
+		{
+			name:    "no change",
+			old:     product("Widget", null.StringFrom("Blue"), "10"),
+			updated: product("Widget", null.StringFrom("Blue"), "10"),
+			want:    map[string]S.FieldChange{},
+		},
+		{
+			name:    "name",
+			old:     product("Widget", null.StringFrom("Blue"), "10"),
+			updated: product("Gadget", null.StringFrom("Blue"), "10"),
+			want:    map[string]S.FieldChange{"name": {Before: "Widget", After: "Gadget"}},
+		},
+		{
+			name:    "null to empty description",
+			old:     product("Widget", null.String{}, "10"),
+			updated: product("Widget", null.StringFrom(""), "10"),
+			want:    map[string]S.FieldChange{"description": {Before: nil, After: ""}},
+		},
+		{
+			name:    "empty to null description",
+			old:     product("Widget", null.StringFrom(""), "10"),
+			updated: product("Widget", null.String{}, "10"),
+			want:    map[string]S.FieldChange{"description": {Before: "", After: nil}},
+		},
+		{
+			name:    "null stays null",
+			old:     product("Widget", null.String{}, "10"),
+			updated: product("Widget", null.String{}, "10"),
+			want:    map[string]S.FieldChange{},
+		},
+		{
+			name:    "same price at another scale",
+			old:     product("Widget", null.String{}, "19.9"),
+			updated: product("Widget", null.String{}, "19.90"),
+			want:    map[string]S.FieldChange{},
+		},
+		{
+			name:    "price",
+			old:     product("Widget", null.String{}, "10"),
+			updated: product("Widget", null.String{}, "12.5"),
+			want:    map[string]S.FieldChange{"price": {Before: "10", After: "12.5"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := S.DiffProducts(tt.old, tt.updated); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("DiffProducts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergePatchProductReturnsChanges(t *testing.T) {
+	service, err := S.NewProductService(S.ProductServiceConfig{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake := testutil.NewFakeExecutor()
+	fake.Push(
+		testutil.ProductsResult(1),
+		testutil.FakeResult{RowsAffected: 1},
+		testutil.FakeResult{RowsAffected: 1},
+	)
+
+	_, changes, _, serviceErr := service.MergePatchProduct(fake, context.Background(), 1, json.RawMessage(`{"name": "Renamed"}`), false)
+	if serviceErr != nil {
+		t.Fatalf("MergePatchProduct() = %q: %v", serviceErr.Message, serviceErr.Error)
+	}
+
+	want := map[string]S.FieldChange{"name": {Before: "Product 1", After: "Renamed"}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Fatalf("MergePatchProduct() changes = %v, want %v", changes, want)
+	}
+}
+
+func TestProductsExistChunks(t *testing.T) {
+	service, err := S.NewProductService(S.ProductServiceConfig{IDsChunkSize: 2}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idRows := func(ids ...int) testutil.FakeResult {
+		result := testutil.FakeResult{Columns: []string{"id"}}
+		for _, id := range ids {
+			result.Rows = append(result.Rows, []driver.Value{int64(id)})
+		}
+		return result
+	}
+
+	fake := testutil.NewFakeExecutor()
+	fake.Push(idRows(1, 2), idRows(3), idRows(5))
+
+	existing, missing, serviceErr := service.ProductsExist(fake, context.Background(), []int{1, 2, 3, 2, 4, 5})
+	if serviceErr != nil {
+		t.Fatalf("ProductsExist() = %q: %v", serviceErr.Message, serviceErr.Error)
+	}
+	if !reflect.DeepEqual(existing, []int{1, 2, 3, 5}) || !reflect.DeepEqual(missing, []int{4}) {
+		t.Fatalf("ProductsExist() = %v, %v; want [1 2 3 5], [4]", existing, missing)
+	}
+
+	calls := fake.Calls()
+	if len(calls) != 3 {
+		t.Fatalf("ProductsExist() ran %d statements, want 3", len(calls))
+	}
+	for _, call := range calls {
+		if len(call.Args) > 2 {
+			t.Fatalf("statement has %d ids, over the chunk size of 2", len(call.Args))
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	M "github.com/atharvbhadange/go-api-template/models"
+	T "github.com/atharvbhadange/go-api-template/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetProductsByNamePrefix returns up to limit products whose name starts with
+// prefix, case-insensitively, in alphabetical order. limit 0 uses the default
+// page size. The match is written as lower(name) LIKE 'prefix%' so it can use a
+// lower(name) text_pattern_ops index, which a leading-wildcard ILIKE cannot.
+func (s *ProductService) GetProductsByNamePrefix(dbTrx boil.ContextExecutor, ctx context.Context, prefix string, limit int) ([]*M.Product, *T.ServiceError) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return nil, &T.ServiceError{
+			Message:     "Prefix is required",
+			MessageCode: T.MsgPrefixRequired,
+			Error:       errors.New("empty prefix"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	if limit < 0 || limit > s.maxPageSize() {
+		return nil, &T.ServiceError{
+			Message:     fmt.Sprintf("Limit must be between 1 and %d", s.maxPageSize()),
+			MessageCode: T.MsgPaginationInvalid,
+			Error:       errors.New("invalid limit"),
+			Code:        fiber.StatusBadRequest,
+		}
+	}
+
+	if limit == 0 {
+		limit = s.defaultPageSize()
+	}
+
+	products, err := M.Products(
+		qm.Where("lower("+M.ProductTableColumns.Name+") LIKE ?", escapeLike(strings.ToLower(prefix))+"%"),
+		qm.OrderBy("lower("+M.ProductTableColumns.Name+"), "+M.ProductTableColumns.ID),
+		qm.Limit(limit),
+	).All(ctx, dbTrx)
+	if err != nil {
+		return nil, &T.ServiceError{
+			Message: "Unable to get products",
+			Error:   err,
+			Code:    dbErrorStatus(err),
+		}
+	}
+
+	if products == nil {
+		products = M.ProductSlice{}
+	}
+
+	return products, nil
+}
@@ -0,0 +1,225 @@
+package services_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	S "github.com/atharvbhadange/go-api-template/api/v1/services"
+	"github.com/atharvbhadange/go-api-template/api/v1/services/testutil"
+	C "github.com/atharvbhadange/go-api-template/constants"
+	T "github.com/atharvbhadange/go-api-template/types"
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+)
+
+func TestParsePriceToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		want    string
+		wantErr bool
+	}{
+		{name: "integer", token: "100", want: "100"},
+		{name: "exact decimal", token: "19.99", want: "19.99"},
+		{name: "surrounding whitespace", token: " 5 ", want: "5"},
+		{name: "null is zero", token: "null", want: "0"},
+		{name: "negative zero", token: "-0", want: "0"},
+		{name: "negative zero with scale", token: "-0.00", want: "0"},
+		{name: "small exponent", token: "1e2", want: "100"},
+		{name: "negative number parses", token: "-1.5", want: "-1.5"},
+		{name: "NaN", token: "NaN", wantErr: true},
+		{name: "negative NaN", token: "-NaN", wantErr: true},
+		{name: "Infinity", token: "Infinity", wantErr: true},
+		{name: "negative Infinity", token: "-Infinity", wantErr: true},
+		{name: "quoted number", token: `"19.99"`, wantErr: true},
+		{name: "boolean", token: "true", wantErr: true},
+		{name: "hex", token: "0x10", wantErr: true},
+		{name: "empty", token: "", wantErr: true},
+		{name: "lone minus", token: "-", wantErr: true},
+		{name: "huge exponent", token: "1e1000000", wantErr: true},
+		{name: "tiny exponent", token: "1e-1000000", wantErr: true},
+		{name: "overlong token", token: strings.Repeat("9", 65), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := S.ParsePriceToken(json.RawMessage(tt.token))
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParsePriceToken(%q) = %s, want an error", tt.token, got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParsePriceToken(%q) returned %v", tt.token, err)
+			}
+			if !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Fatalf("ParsePriceToken(%q) = %s, want %s", tt.token, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProductBodyValidate(t *testing.T) {
+	maxName := strings.Repeat("é", C.PRODUCT_NAME_MAX_LENGTH)
+
+	tests := []struct {
+		name     string
+		body     *S.ProductBody
+		wantCode string
+	}{
+		{name: "valid", body: testutil.NewProductBody().Build()},
+		{name: "zero price", body: testutil.NewProductBody().WithPrice(decimal.Zero).Build()},
+		{name: "negative zero price", body: testutil.NewProductBody().WithPrice(decimal.RequireFromString("-0.00")).Build()},
+		{name: "negative price", body: testutil.NewProductBody().WithPrice(decimal.RequireFromString("-0.01")).Build(), wantCode: T.MsgProductPriceNegative},
+		{name: "too many decimals", body: testutil.NewProductBody().WithPrice(decimal.RequireFromString("1.001")).Build(), wantCode: T.MsgProductPriceScale},
+		{name: "empty name", body: testutil.NewProductBody().WithName("").Build(), wantCode: T.MsgProductNameRequired},
+		{name: "blank name", body: testutil.NewProductBody().WithName(" \t ").Build(), wantCode: T.MsgProductNameRequired},
+		{name: "name with NUL", body: testutil.NewProductBody().WithName("Wid\x00get").Build(), wantCode: T.MsgProductNameInvalid},
+		{name: "name with escape", body: testutil.NewProductBody().WithName("\x1b[31mWidget").Build(), wantCode: T.MsgProductNameInvalid},
+		{name: "name with newline", body: testutil.NewProductBody().WithName("Wid\nget").Build(), wantCode: T.MsgProductNameInvalid},
+		{name: "name with C1 control", body: testutil.NewProductBody().WithName("Widget\u0085").Build(), wantCode: T.MsgProductNameInvalid},
+		{name: "name with invalid UTF-8", body: testutil.NewProductBody().WithName("Widget\xff").Build(), wantCode: T.MsgProductNameInvalid},
+		{name: "longest unicode name", body: testutil.NewProductBody().WithName(maxName).Build()},
+		{name: "unicode name one rune too long", body: testutil.NewProductBody().WithName(maxName + "é").Build(), wantCode: T.MsgProductNameTooLong},
+		{name: "emoji name too long", body: testutil.NewProductBody().WithName(strings.Repeat("🧪", C.PRODUCT_NAME_MAX_LENGTH+1)).Build(), wantCode: T.MsgProductNameTooLong},
+		{name: "description with line breaks", body: testutil.NewProductBody().WithDescription("line one\r\nline two\tend").Build()},
+		{name: "description with NUL", body: testutil.NewProductBody().WithDescription("bad\x00text").Build(), wantCode: T.MsgProductDescriptionInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceErr := tt.body.Validate()
+
+			if tt.wantCode == "" {
+				if serviceErr != nil {
+					t.Fatalf("Validate() = %q, want no error", serviceErr.Message)
+				}
+				return
+			}
+
+			if serviceErr == nil {
+				t.Fatalf("Validate() = nil, want %s", tt.wantCode)
+			}
+			if serviceErr.MessageCode != tt.wantCode {
+				t.Fatalf("Validate() code = %s, want %s", serviceErr.MessageCode, tt.wantCode)
+			}
+			if serviceErr.Code != fiber.StatusBadRequest {
+				t.Fatalf("Validate() status = %d, want %d", serviceErr.Code, fiber.StatusBadRequest)
+			}
+		})
+	}
+}
+
+func TestValidateDescriptionText(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		wantErr     bool
+	}{
+		{name: "empty", description: ""},
+		{name: "plain", description: "A sturdy widget"},
+		{name: "unicode", description: "Größe: 10 cm, 色: 赤"},
+		{name: "line breaks and tabs", description: "one\ntwo\r\nthree\tfour"},
+		{name: "NUL", description: "a\x00b", wantErr: true},
+		{name: "bell", description: "a\x07b", wantErr: true},
+		{name: "escape sequence", description: "\x1b[0m", wantErr: true},
+		{name: "DEL", description: "a\x7fb", wantErr: true},
+		{name: "C1 control", description: "a\u009bb", wantErr: true},
+		{name: "invalid UTF-8", description: "a\xc3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceErr := S.ValidateDescriptionText(tt.description)
+
+			if tt.wantErr && serviceErr == nil {
+				t.Fatalf("ValidateDescriptionText(%q) = nil, want an error", tt.description)
+			}
+			if !tt.wantErr && serviceErr != nil {
+				t.Fatalf("ValidateDescriptionText(%q) = %q, want no error", tt.description, serviceErr.Message)
+			}
+			if serviceErr != nil && serviceErr.MessageCode != T.MsgProductDescriptionInvalid {
+				t.Fatalf("ValidateDescriptionText(%q) code = %s, want %s", tt.description, serviceErr.MessageCode, T.MsgProductDescriptionInvalid)
+			}
+		})
+	}
+}
+
+// Invalid input must be rejected before any statement reaches the database, on
+// create and on update alike.
+func TestWritesRejectInvalidBodies(t *testing.T) {
+	productColumns := []string{"id", "name", "price", "description"}
+
+	tests := []struct {
+		name     string
+		body     *S.ProductBody
+		wantCode string
+	}{
+		{name: "control character in name", body: testutil.NewProductBody().WithName("Wid\x00get").Build(), wantCode: T.MsgProductNameInvalid},
+		{name: "name too long", body: testutil.NewProductBody().WithName(strings.Repeat("ü", C.PRODUCT_NAME_MAX_LENGTH+1)).Build(), wantCode: T.MsgProductNameTooLong},
+		{name: "negative price", body: testutil.NewProductBody().WithPrice(decimal.NewFromInt(-1)).Build(), wantCode: T.MsgProductPriceNegative},
+		{name: "missing price", body: &S.ProductBody{Name: "Widget"}, wantCode: T.MsgProductPriceRequired},
+	}
+
+	service, err := S.NewProductService(S.ProductServiceConfig{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run("create/"+tt.name, func(t *testing.T) {
+			fake := testutil.NewFakeExecutor()
+
+			_, serviceErr := service.CreateProduct(fake, context.Background(), tt.body)
+
+			if serviceErr == nil || serviceErr.MessageCode != tt.wantCode {
+				t.Fatalf("CreateProduct() = %v, want %s", serviceErr, tt.wantCode)
+			}
+			if calls := fake.Calls(); len(calls) != 0 {
+				t.Fatalf("CreateProduct() ran %d statements, want none", len(calls))
+			}
+		})
+
+		t.Run("update/"+tt.name, func(t *testing.T) {
+			fake := testutil.NewFakeExecutor()
+			fake.Push(testutil.FakeResult{
+				Columns: productColumns,
+				Rows:    [][]driver.Value{{int64(1), "Widget", "10.00", "A widget"}},
+			})
+
+			_, _, _, serviceErr := service.UpdateProduct(fake, context.Background(), 1, tt.body, false)
+
+			if serviceErr == nil || serviceErr.MessageCode != tt.wantCode {
+				t.Fatalf("UpdateProduct() = %v, want %s", serviceErr, tt.wantCode)
+			}
+			if calls := fake.Calls(); len(calls) != 1 {
+				t.Fatalf("UpdateProduct() ran %d statements, want only the lookup", len(calls))
+			}
+		})
+	}
+}
+
+func TestUpdateProductNotFound(t *testing.T) {
+	service, err := S.NewProductService(S.ProductServiceConfig{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake := testutil.NewFakeExecutor()
+	fake.Push(testutil.FakeResult{Columns: []string{"id", "name", "price", "description"}})
+
+	_, _, _, serviceErr := service.UpdateProduct(fake, context.Background(), 1, testutil.NewProductBody().Build(), false)
+
+	if serviceErr == nil || serviceErr.Code != fiber.StatusNotFound {
+		t.Fatalf("UpdateProduct() = %v, want 404", serviceErr)
+	}
+	if serviceErr.MessageCode != T.MsgProductNotFound {
+		t.Fatalf("UpdateProduct() code = %s, want %s", serviceErr.MessageCode, T.MsgProductNotFound)
+	}
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+
+	T "github.com/atharvbhadange/go-api-template/types"
+)
+
+// ProductValidator is a deployment specific rule run by CreateProduct and
+// UpdateProduct after the built-in checks have passed.
+type ProductValidator interface {
+	Validate(ctx context.Context, body *ProductBody) *T.ServiceError
+}
+
+type ProductValidatorFunc func(ctx context.Context, body *ProductBody) *T.ServiceError
+
+func (f ProductValidatorFunc) Validate(ctx context.Context, body *ProductBody) *T.ServiceError {
+	return f(ctx, body)
+}
+
+// RegisterProductValidator appends validators to the chain of DefaultProductService.
+// It is meant to be called during startup, before the app starts serving requests.
+func RegisterProductValidator(validators ...ProductValidator) {
+	DefaultProductService.Config.Validators = append(DefaultProductService.Config.Validators, validators...)
+}
+
+func (s *ProductService) runValidators(ctx context.Context, body *ProductBody) *T.ServiceError {
+	for _, validator := range s.Config.Validators {
+		if serviceErr := validator.Validate(ctx, body); serviceErr != nil {
+			return serviceErr
+		}
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/types"
+	M "github.com/atharvbhadange/go-api-template/models"
+	T "github.com/atharvbhadange/go-api-template/types"
+	"github.com/shopspring/decimal"
+)
+
+// Package-level wrappers around DefaultProductService, kept so existing callers
+// do not need a service instance.
+
+func GetProducts(dbTrx boil.ContextExecutor, ctx context.Context) ([]*M.Product, *T.ServiceError) {
+	products, _, serviceErr := DefaultProductService.GetProducts(dbTrx, ctx)
+	return products, serviceErr
+}
+
+// GetProductsWithMeta is GetProducts with the ListMeta that reports truncation
+// by SoftResultLimit.
+func GetProductsWithMeta(dbTrx boil.ContextExecutor, ctx context.Context) ([]*M.Product, ListMeta, *T.ServiceError) {
+	return DefaultProductService.GetProducts(dbTrx, ctx)
+}
+
+func GetProduct(dbTrx boil.ContextExecutor, ctx context.Context, id int) (*M.Product, *T.ServiceError) {
+	return DefaultProductService.GetProduct(dbTrx, ctx, id)
+}
+
+func GetProductsByIDs(dbTrx boil.ContextExecutor, ctx context.Context, ids []int) ([]*M.Product, *T.ServiceError) {
+	return DefaultProductService.GetProductsByIDs(dbTrx, ctx, ids)
+}
+
+func GetProductsByExactPrice(dbTrx boil.ContextExecutor, ctx context.Context, price types.Decimal) ([]*M.Product, *T.ServiceError) {
+	return DefaultProductService.GetProductsByExactPrice(dbTrx, ctx, price)
+}
+
+func ProductsExist(dbTrx boil.ContextExecutor, ctx context.Context, ids []int) ([]int, []int, *T.ServiceError) {
+	return DefaultProductService.ProductsExist(dbTrx, ctx, ids)
+}
+
+func CreateProduct(dbTrx boil.ContextExecutor, ctx context.Context, body *ProductBody) (*M.Product, *T.ServiceError) {
+	return DefaultProductService.CreateProduct(dbTrx, ctx, body)
+}
+
+func CreateOrGetProduct(dbTrx boil.ContextExecutor, ctx context.Context, body *ProductBody) (*M.Product, bool, *T.ServiceError) {
+	return DefaultProductService.CreateOrGetProduct(dbTrx, ctx, body)
+}
+
+func UpdateProduct(dbTrx boil.ContextExecutor, ctx context.Context, id int, body *ProductBody, force bool) (*M.Product, map[string]FieldChange, *T.ServiceError) {
+	product, changes, _, serviceErr := DefaultProductService.UpdateProduct(dbTrx, ctx, id, body, force)
+	return product, changes, serviceErr
+}
+
+// UpdateProductWithMeta is UpdateProduct that also returns the price watches
+// the update met, for callers that deliver them with RunPriceWatchHooks.
+func UpdateProductWithMeta(dbTrx boil.ContextExecutor, ctx context.Context, id int, body *ProductBody, force bool) (*M.Product, map[string]FieldChange, []*PriceWatch, *T.ServiceError) {
+	return DefaultProductService.UpdateProduct(dbTrx, ctx, id, body, force)
+}
+
+func MergePatchProduct(dbTrx boil.ContextExecutor, ctx context.Context, id int, raw json.RawMessage) (*M.Product, *T.ServiceError) {
+	product, _, _, serviceErr := DefaultProductService.MergePatchProduct(dbTrx, ctx, id, raw, false)
+	return product, serviceErr
+}
+
+// MergePatchProductWithMeta is MergePatchProduct with force and, like
+// UpdateProductWithMeta, the changes and met price watches.
+func MergePatchProductWithMeta(dbTrx boil.ContextExecutor, ctx context.Context, id int, raw json.RawMessage, force bool) (*M.Product, map[string]FieldChange, []*PriceWatch, *T.ServiceError) {
+	return DefaultProductService.MergePatchProduct(dbTrx, ctx, id, raw, force)
+}
+
+func DeleteProduct(dbTrx boil.ContextExecutor, ctx context.Context, id int) *T.ServiceError {
+	return DefaultProductService.DeleteProduct(dbTrx, ctx, id)
+}
+
+func DeleteProductIfExists(dbTrx boil.ContextExecutor, ctx context.Context, id int) (bool, *T.ServiceError) {
+	return DefaultProductService.DeleteProductIfExists(dbTrx, ctx, id)
+}
+
+func CompareProducts(dbTrx boil.ContextExecutor, ctx context.Context, ids []int) (*ProductComparison, *T.ServiceError) {
+	return DefaultProductService.CompareProducts(dbTrx, ctx, ids)
+}
+
+func GetProductsNeedingAttention(dbTrx boil.ContextExecutor, ctx context.Context) ([]*ProductAttention, *T.ServiceError) {
+	return DefaultProductService.GetProductsNeedingAttention(dbTrx, ctx)
+}
+
+func ExportProductsJSONL(dbTrx boil.ContextExecutor, ctx context.Context, w io.Writer, compress bool) *T.ServiceError {
+	return DefaultProductService.ExportProductsJSONL(dbTrx, ctx, w, compress)
+}
+
+func GetProductSummaries(dbTrx boil.ContextExecutor, ctx context.Context, filter ProductFilter) ([]*ProductSummary, *T.ServiceError) {
+	summaries, _, serviceErr := DefaultProductService.GetProductSummaries(dbTrx, ctx, filter)
+	return summaries, serviceErr
+}
+
+func GetProductSummariesWithMeta(dbTrx boil.ContextExecutor, ctx context.Context, filter ProductFilter) ([]*ProductSummary, ListMeta, *T.ServiceError) {
+	return DefaultProductService.GetProductSummaries(dbTrx, ctx, filter)
+}
+
+func ImportProductsJSONStream(dbTrx boil.ContextExecutor, ctx context.Context, r io.Reader, progress func(ImportResult)) (*ImportResult, *T.ServiceError) {
+	return DefaultProductService.ImportProductsJSONStream(dbTrx, ctx, r, progress)
+}
+
+func ParseProductFields(fields []string) ([]string, *T.ServiceError) {
+	return DefaultProductService.ParseProductFields(fields)
+}
+
+func GetProductsWithFields(dbTrx boil.ContextExecutor, ctx context.Context, fields []string) ([]map[string]interface{}, *T.ServiceError) {
+	products, _, serviceErr := DefaultProductService.GetProductsWithFields(dbTrx, ctx, fields)
+	return products, serviceErr
+}
+
+func GetProductsWithFieldsWithMeta(dbTrx boil.ContextExecutor, ctx context.Context, fields []string) ([]map[string]interface{}, ListMeta, *T.ServiceError) {
+	return DefaultProductService.GetProductsWithFields(dbTrx, ctx, fields)
+}
+
+func GetProductWithFields(dbTrx boil.ContextExecutor, ctx context.Context, id int, fields []string) (map[string]interface{}, *T.ServiceError) {
+	return DefaultProductService.GetProductWithFields(dbTrx, ctx, id, fields)
+}
+
+func GetProductsPaginated(dbTrx boil.ContextExecutor, ctx context.Context, filter ProductFilter) (*T.Page[*M.Product], *T.ServiceError) {
+	return DefaultProductService.GetProductsPaginated(dbTrx, ctx, filter)
+}
+
+func BulkCreateProducts(dbTrx boil.ContextExecutor, ctx context.Context, bodies []*ProductBody, partial bool) ([]ItemResult, *T.ServiceError) {
+	return DefaultProductService.BulkCreateProducts(dbTrx, ctx, bodies, partial)
+}
+
+func GetProductsByIDsForUpdate(dbTrx boil.ContextExecutor, ctx context.Context, ids []int) ([]*M.Product, *T.ServiceError) {
+	return DefaultProductService.GetProductsByIDsForUpdate(dbTrx, ctx, ids)
+}
+
+func GetProductsByNamePrefix(dbTrx boil.ContextExecutor, ctx context.Context, prefix string, limit int) ([]*M.Product, *T.ServiceError) {
+	return DefaultProductService.GetProductsByNamePrefix(dbTrx, ctx, prefix, limit)
+}
+
+func GetProductChanges(dbTrx boil.ContextExecutor, ctx context.Context, action string, from, to time.Time) ([]*AuditEntry, *T.ServiceError) {
+	return DefaultProductService.GetProductChanges(dbTrx, ctx, action, from, to)
+}
+
+func GetPriceHistogram(dbTrx boil.ContextExecutor, ctx context.Context, bucketSize decimal.Decimal) ([]*PriceBucket, *T.ServiceError) {
+	return DefaultProductService.GetPriceHistogram(dbTrx, ctx, bucketSize)
+}
+
+func GetProductsByIDsStrict(dbTrx boil.ContextExecutor, ctx context.Context, ids []int) ([]*M.Product, *T.ServiceError) {
+	return DefaultProductService.GetProductsByIDsStrict(dbTrx, ctx, ids)
+}
+
+func GetProductsByIDsSorted(dbTrx boil.ContextExecutor, ctx context.Context, ids []int, sort string) ([]*M.Product, *T.ServiceError) {
+	return DefaultProductService.GetProductsByIDsSorted(dbTrx, ctx, ids, sort)
+}
+
+func PreviewImportConflicts(dbTrx boil.ContextExecutor, ctx context.Context, bodies []*ProductBody) ([]ImportConflict, *T.ServiceError) {
+	return DefaultProductService.PreviewImportConflicts(dbTrx, ctx, bodies)
+}
+
+func StreamProductsJSON(dbTrx boil.ContextExecutor, ctx context.Context, w io.Writer) *T.ServiceError {
+	return DefaultProductService.StreamProductsJSON(dbTrx, ctx, w)
+}
+
+func GetProductsByIDsCached(dbTrx boil.ContextExecutor, ctx context.Context, ids []int) ([]*M.Product, []int, *T.ServiceError) {
+	return DefaultProductService.GetProductsByIDsCached(dbTrx, ctx, ids)
+}
+
+func GetProductsWithPriceChangeSince(dbTrx boil.ContextExecutor, ctx context.Context, since time.Time) ([]*PriceChange, *T.ServiceError) {
+	return DefaultProductService.GetProductsWithPriceChangeSince(dbTrx, ctx, since)
+}
+
+func BulkUpdateProducts(dbTrx boil.ContextExecutor, ctx context.Context, updates []ProductUpdate) ([]*M.Product, []int, *T.ServiceError) {
+	return DefaultProductService.BulkUpdateProducts(dbTrx, ctx, updates)
+}
+
+func SnapshotProductForOrder(dbTrx boil.ContextExecutor, ctx context.Context, id int) (ProductSnapshot, *T.ServiceError) {
+	return DefaultProductService.SnapshotProductForOrder(dbTrx, ctx, id)
+}
+
+func StreamProductsByIDs(dbTrx boil.ContextExecutor, ctx context.Context, ids []int, fn func(product *M.Product) error) *T.ServiceError {
+	return DefaultProductService.StreamProductsByIDs(dbTrx, ctx, ids, fn)
+}
+
+func GetProductsChangedByUserBetween(dbTrx boil.ContextExecutor, ctx context.Context, userID string, from, to time.Time) ([]*UserChange, *T.ServiceError) {
+	return DefaultProductService.GetProductsChangedByUserBetween(dbTrx, ctx, userID, from, to)
+}
+
+func GetProductsUpdatedBy(dbTrx boil.ContextExecutor, ctx context.Context, userID string) ([]*M.Product, *T.ServiceError) {
+	return DefaultProductService.GetProductsUpdatedBy(dbTrx, ctx, userID)
+}
+
+func GetProductsByIDString(dbTrx boil.ContextExecutor, ctx context.Context, raw string) ([]*M.Product, *T.ServiceError) {
+	return DefaultProductService.GetProductsByIDString(dbTrx, ctx, raw)
+}
+
+func RunProductCreatedHooks(ctx context.Context, product *M.Product) {
+	DefaultProductService.RunProductCreatedHooks(ctx, product)
+}
+
+func RunProductUpdatedHooks(ctx context.Context, product *M.Product) {
+	DefaultProductService.RunProductUpdatedHooks(ctx, product)
+}
+
+func RunProductDeletedHooks(ctx context.Context, product *M.Product) {
+	DefaultProductService.RunProductDeletedHooks(ctx, product)
+}
+
+func CountProductsMatching(dbTrx boil.ContextExecutor, ctx context.Context, filter ProductFilter) (int64, *T.ServiceError) {
+	return DefaultProductService.CountProductsMatching(dbTrx, ctx, filter)
+}
+
+func DeleteProductsMatching(dbTrx boil.ContextExecutor, ctx context.Context, filter ProductFilter, expectedCount int64) ([]*M.Product, *T.ServiceError) {
+	return DefaultProductService.DeleteProductsMatching(dbTrx, ctx, filter, expectedCount)
+}
+
+func GetProductsByIDsWithFields(dbTrx boil.ContextExecutor, ctx context.Context, ids []int, fields []string) ([]map[string]interface{}, *T.ServiceError) {
+	return DefaultProductService.GetProductsByIDsWithFields(dbTrx, ctx, ids, fields)
+}
+
+func CheckPriceWatches(dbTrx boil.ContextExecutor, ctx context.Context, productID int, newPrice decimal.Decimal) ([]*PriceWatch, *T.ServiceError) {
+	return DefaultProductService.CheckPriceWatches(dbTrx, ctx, productID, newPrice)
+}
+
+func RunPriceWatchHooks(ctx context.Context, product *M.Product, watches []*PriceWatch) {
+	DefaultProductService.RunPriceWatchHooks(ctx, product, watches)
+}
+
+func PollOutbox(dbTrx boil.ContextExecutor, ctx context.Context, limit int) ([]*OutboxEvent, *T.ServiceError) {
+	return DefaultProductService.PollOutbox(dbTrx, ctx, limit)
+}
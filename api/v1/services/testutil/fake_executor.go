@@ -0,0 +1,168 @@
+package testutil
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+)
+
+// FakeResult is the canned response for one statement. Queries answer with
+// Columns and Rows, statements with RowsAffected and LastInsertID. A query with
+// Columns and no Rows makes QueryRow report sql.ErrNoRows.
+type FakeResult struct {
+	Columns      []string
+	Rows         [][]driver.Value
+	RowsAffected int64
+	LastInsertID int64
+	Err          error
+}
+
+type Call struct {
+	Query string
+	Args  []driver.Value
+}
+
+// FakeExecutor is an in-memory boil.ContextExecutor for service tests. It is a
+// real *sql.DB backed by a fake driver, because sqlboiler needs *sql.Rows, which
+// only a driver can produce. Results are handed out in the order they were pushed.
+type FakeExecutor struct {
+	*sql.DB
+
+	mu      sync.Mutex
+	results []FakeResult
+	calls   []Call
+}
+
+var _ boil.ContextExecutor = (*FakeExecutor)(nil)
+
+var (
+	registerOnce sync.Once
+	fakeCount    atomic.Int64
+	fakes        sync.Map
+)
+
+const driverName = "services-testutil-fake"
+
+func NewFakeExecutor() *FakeExecutor {
+	registerOnce.Do(func() { sql.Register(driverName, fakeDriver{}) })
+
+	name := fmt.Sprintf("fake-%d", fakeCount.Add(1))
+	fake := &FakeExecutor{}
+	fakes.Store(name, fake)
+
+	db, err := sql.Open(driverName, name)
+	if err != nil {
+		panic(err)
+	}
+	fake.DB = db
+
+	return fake
+}
+
+func (f *FakeExecutor) Push(results ...FakeResult) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.results = append(f.results, results...)
+}
+
+// Calls returns every statement executed so far, in order.
+func (f *FakeExecutor) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+func (f *FakeExecutor) next(query string, args []driver.NamedValue) (FakeResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	f.calls = append(f.calls, Call{Query: query, Args: values})
+
+	if len(f.results) == 0 {
+		return FakeResult{}, fmt.Errorf("testutil: no result pushed for query %q", query)
+	}
+
+	result := f.results[0]
+	f.results = f.results[1:]
+	return result, result.Err
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	fake, ok := fakes.Load(name)
+	if !ok {
+		return nil, fmt.Errorf("testutil: unknown fake %q", name)
+	}
+	return &fakeConn{fake: fake.(*FakeExecutor)}, nil
+}
+
+type fakeConn struct {
+	fake *FakeExecutor
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("testutil: prepared statements are not supported")
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) { return fakeTx{}, nil }
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	result, err := c.fake.next(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &fakeRows{columns: result.Columns, rows: result.Rows}, nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	result, err := c.fake.next(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return fakeExecResult{rowsAffected: result.RowsAffected, lastInsertID: result.LastInsertID}, nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeExecResult struct {
+	rowsAffected int64
+	lastInsertID int64
+}
+
+func (r fakeExecResult) LastInsertId() (int64, error) { return r.lastInsertID, nil }
+func (r fakeExecResult) RowsAffected() (int64, error) { return r.rowsAffected, nil }
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,44 @@
+// Package testutil holds helpers for tests of the services package.
+// It is only imported from _test.go files, so it is never linked into the app binary.
+package testutil
+
+import (
+	S "github.com/atharvbhadange/go-api-template/api/v1/services"
+	"github.com/shopspring/decimal"
+)
+
+type ProductBodyBuilder struct {
+	body S.ProductBody
+}
+
+// NewProductBody starts from a body that passes ProductBody.Validate.
+func NewProductBody() *ProductBodyBuilder {
+	return &ProductBodyBuilder{
+		body: S.ProductBody{
+			Name:        "Test product",
+			Description: "A product used in tests",
+			Price:       S.PriceFromDecimal(decimal.NewFromInt(100)),
+		},
+	}
+}
+
+func (b *ProductBodyBuilder) WithName(name string) *ProductBodyBuilder {
+	b.body.Name = name
+	return b
+}
+
+func (b *ProductBodyBuilder) WithDescription(description string) *ProductBodyBuilder {
+	b.body.Description = description
+	return b
+}
+
+func (b *ProductBodyBuilder) WithPrice(price decimal.Decimal) *ProductBodyBuilder {
+	b.body.Price = S.PriceFromDecimal(price)
+	return b
+}
+
+// Build returns a copy, so a builder can be reused for several bodies.
+func (b *ProductBodyBuilder) Build() *S.ProductBody {
+	body := b.body
+	return &body
+}
@@ -0,0 +1,32 @@
+package testutil_test
+
+import (
+	"testing"
+
+	"github.com/atharvbhadange/go-api-template/api/v1/services/testutil"
+	"github.com/shopspring/decimal"
+)
+
+func TestNewProductBodyIsValid(t *testing.T) {
+	if serviceErr := testutil.NewProductBody().Build().Validate(); serviceErr != nil {
+		t.Fatalf("default body fails Validate: %q", serviceErr.Message)
+	}
+}
+
+func TestProductBodyBuilder(t *testing.T) {
+	builder := testutil.NewProductBody().
+		WithName("Widget").
+		WithDescription("Blue").
+		WithPrice(decimal.RequireFromString("12.50"))
+
+	body := builder.Build()
+	if body.Name != "Widget" || body.Description != "Blue" || !body.Price.Amount().Equal(decimal.RequireFromString("12.5")) {
+		t.Fatalf("Build() = %+v, want Widget, Blue, 12.50", body)
+	}
+
+	// Build copies, so changing one body leaves the builder and later bodies alone.
+	body.Name = "Changed"
+	if again := builder.Build(); again.Name != "Widget" {
+		t.Fatalf("Build() after changing an earlier body = %q, want Widget", again.Name)
+	}
+}
@@ -0,0 +1,27 @@
+package testutil
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// ProductColumns are the columns of a products row, in table order.
+var ProductColumns = []string{"id", "name", "price", "description"}
+
+// ProductsResult answers a products query with one valid row per id.
+func ProductsResult(ids ...int) FakeResult {
+	rows := make([][]driver.Value, len(ids))
+	for i, id := range ids {
+		rows[i] = []driver.Value{int64(id), fmt.Sprintf("Product %d", id), "10.00", "A product used in tests"}
+	}
+	return FakeResult{Columns: ProductColumns, Rows: rows}
+}
+
+// IDRange returns the ids from through to, inclusive.
+func IDRange(from, to int) []int {
+	ids := make([]int, 0, to-from+1)
+	for id := from; id <= to; id++ {
+		ids = append(ids, id)
+	}
+	return ids
+}
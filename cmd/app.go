@@ -1,15 +1,42 @@
 package cmd
 
 import (
+	"log"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/requestid"
 
 	"github.com/atharvbhadange/go-api-template/api/v1/routes"
+	S "github.com/atharvbhadange/go-api-template/api/v1/services"
+	"github.com/atharvbhadange/go-api-template/config"
 	H "github.com/atharvbhadange/go-api-template/handler"
 )
 
 func InitApp() *fiber.App {
+	// Start from the current config so settings made in code before InitApp,
+	// such as validators, hooks and policies, are kept; only the fields backed
+	// by environment variables are replaced.
+	serviceConfig := S.DefaultProductService.Config
+	serviceConfig.PriceChangeGuardPercent = config.Conf.PriceChangeMaxPercent
+	serviceConfig.OperationTimeout = config.Conf.DBOperationTimeout
+	serviceConfig.SoftResultLimit = config.Conf.SoftResultLimit
+	serviceConfig.DefaultPageSize = config.Conf.DefaultPageSize
+	serviceConfig.MaxPageSize = config.Conf.MaxPageSize
+	serviceConfig.MaxDescriptionLength = config.Conf.MaxDescriptionLength
+	serviceConfig.ApproximateCountMinRows = config.Conf.ApproximateCountMinRows
+	serviceConfig.Outbox = config.Conf.OutboxEnabled
+	serviceConfig.PriceWatches = config.Conf.PriceWatchesEnabled
+
+	productService, err := S.NewProductService(serviceConfig, S.DefaultProductService.Logger)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	S.DefaultProductService = productService
+	S.SetMaintenanceMode(config.Conf.MaintenanceMode, config.Conf.MaintenanceRetryAfter)
+
 	app := fiber.New(
 		fiber.Config{
 			ErrorHandler: H.ErrorHandler,
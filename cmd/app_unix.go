@@ -0,0 +1,28 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	S "github.com/atharvbhadange/go-api-template/api/v1/services"
+	"github.com/atharvbhadange/go-api-template/config"
+)
+
+// WatchMaintenanceSignal flips maintenance mode every time the process receives
+// SIGUSR1, so ops can gate writes without a redeploy.
+func WatchMaintenanceSignal() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1)
+
+	go func() {
+		for range signals {
+			enabled := !S.MaintenanceMode()
+			S.SetMaintenanceMode(enabled, config.Conf.MaintenanceRetryAfter)
+			log.Printf("Maintenance mode enabled: %v", enabled)
+		}
+	}()
+}
@@ -0,0 +1,7 @@
+//go:build windows
+
+package cmd
+
+// WatchMaintenanceSignal does nothing on Windows, which has no SIGUSR1.
+// Maintenance mode is still set from MAINTENANCE_MODE at startup.
+func WatchMaintenanceSignal() {}
@@ -27,6 +27,23 @@ type Config struct {
 	PostgresMaxOpenConns int
 	PostgresMaxIdleConns int
 	PostgresMaxIdleTime  time.Duration
+
+	SerializationRetries int
+
+	PriceChangeMaxPercent int
+	DBOperationTimeout    time.Duration
+	SoftResultLimit       int
+	DefaultPageSize       int
+	MaxPageSize           int
+	MaxDescriptionLength  int
+
+	ApproximateCountMinRows int
+
+	OutboxEnabled       bool
+	PriceWatchesEnabled bool
+
+	MaintenanceMode       bool
+	MaintenanceRetryAfter time.Duration
 }
 
 type confVars struct {
@@ -57,6 +74,21 @@ func New() (*Config, error) {
 	postgresMaxIdleConns := vars.optionalInt("POSTGRES_MAX_IDLE_CONNS", constants.POSTGRES_MAX_IDLE_CONNS)
 	postgresMaxIdleTime := vars.optionalDuration("POSTGRES_MAX_IDLE_TIME", 5*time.Minute)
 
+	serializationRetries := vars.optionalInt("SERIALIZATION_RETRIES", constants.SERIALIZATION_RETRIES)
+
+	priceChangeMaxPercent := vars.optionalInt("PRICE_CHANGE_MAX_PERCENT", 0)
+	dbOperationTimeout := vars.optionalDuration("DB_OPERATION_TIMEOUT", constants.DB_OPERATION_TIMEOUT)
+	softResultLimit := vars.optionalInt("SOFT_RESULT_LIMIT", 0)
+	defaultPageSize := vars.optionalInt("DEFAULT_PAGE_SIZE", constants.DEFAULT_PAGE_SIZE)
+	maxPageSize := vars.optionalInt("MAX_PAGE_SIZE", constants.MAX_PAGE_SIZE)
+	maxDescriptionLength := vars.optionalInt("MAX_DESCRIPTION_LENGTH", 0)
+	approximateCountMinRows := vars.optionalInt("APPROXIMATE_COUNT_MIN_ROWS", 0)
+	outboxEnabled := vars.optionalBool("OUTBOX_ENABLED", false)
+	priceWatchesEnabled := vars.optionalBool("PRICE_WATCHES_ENABLED", false)
+
+	maintenanceMode := vars.optionalBool("MAINTENANCE_MODE", false)
+	maintenanceRetryAfter := vars.optionalDuration("MAINTENANCE_RETRY_AFTER", 5*time.Minute)
+
 	if err := vars.Error(); err != nil {
 		return nil, fmt.Errorf("error loading configuration: %w", err)
 	}
@@ -78,6 +110,23 @@ func New() (*Config, error) {
 		PostgresMaxOpenConns: postgresMaxOpenConns,
 		PostgresMaxIdleConns: postgresMaxIdleConns,
 		PostgresMaxIdleTime:  postgresMaxIdleTime,
+
+		SerializationRetries: serializationRetries,
+
+		PriceChangeMaxPercent: priceChangeMaxPercent,
+		DBOperationTimeout:    dbOperationTimeout,
+		SoftResultLimit:       softResultLimit,
+		DefaultPageSize:       defaultPageSize,
+		MaxPageSize:           maxPageSize,
+		MaxDescriptionLength:  maxDescriptionLength,
+
+		ApproximateCountMinRows: approximateCountMinRows,
+
+		OutboxEnabled:       outboxEnabled,
+		PriceWatchesEnabled: priceWatchesEnabled,
+
+		MaintenanceMode:       maintenanceMode,
+		MaintenanceRetryAfter: maintenanceRetryAfter,
 	}
 
 	Conf = config
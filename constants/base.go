@@ -1,8 +1,34 @@
 package constants
 
+import "time"
+
 const (
 	POSTGRES_MAX_IDLE_CONNS = 25
 	POSTGRES_MAX_OPEN_CONNS = 25
+
+	SERIALIZATION_RETRIES = 3
+)
+
+const (
+	COMPARE_PRODUCTS_MAX = 4
+	// Products do not carry a currency yet, so every price is in this one.
+	DEFAULT_CURRENCY = "USD"
+	DEFAULT_LOCALE   = "en"
+
+	PRODUCT_IDS_CHUNK_SIZE  = 1000
+	PRODUCT_IDS_MAX         = 1000
+	PRODUCT_NAME_MAX_LENGTH = 255
+
+	IMPORT_BATCH_SIZE = 500
+	OUTBOX_POLL_MAX   = 500
+
+	// Rows per multi-row INSERT, well below Postgres's 65535 bind parameters.
+	INSERT_BATCH_SIZE = 1000
+
+	DEFAULT_PAGE_SIZE = 20
+	MAX_PAGE_SIZE     = 100
+
+	DB_OPERATION_TIMEOUT = 5 * time.Second
 )
 
 const (
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+
+	C "github.com/atharvbhadange/go-api-template/config"
+)
+
+const pqSerializationFailure = "40001"
+
+// PGTransactionWithOptions begins a transaction with explicit options, such as
+// an isolation level. PGTransaction uses the server default, READ COMMITTED.
+func PGTransactionWithOptions(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return PostgresConn.BeginTx(ctx, opts)
+}
+
+// WithTransaction runs fn in a transaction begun with opts, committing when fn
+// returns nil and rolling back otherwise. When fn or the commit fails with a
+// serialization failure the whole transaction is retried, up to
+// C.Conf.SerializationRetries more times, so fn must be safe to run again.
+//
+// Reads and single-row writes are fine with READ COMMITTED. Use
+// sql.LevelSerializable where a decision is made from rows read earlier in the
+// same transaction and a concurrent writer could invalidate it: stock
+// reservations, price changes checked against the current price, bulk updates
+// and imports that check for existing names first.
+func WithTransaction(ctx context.Context, opts *sql.TxOptions, fn func(tx *sql.Tx) error) error {
+	retries := C.Conf.SerializationRetries
+
+	for attempt := 0; ; attempt++ {
+		err := runTransaction(ctx, opts, fn)
+		if err == nil || !isSerializationFailure(err) || attempt >= retries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt+1) * 10 * time.Millisecond):
+		}
+	}
+}
+
+func runTransaction(ctx context.Context, opts *sql.TxOptions, fn func(tx *sql.Tx) error) error {
+	tx, err := PGTransactionWithOptions(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func isSerializationFailure(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == pqSerializationFailure
+}
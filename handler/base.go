@@ -1,12 +1,38 @@
 package handler
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"strconv"
+
+	T "github.com/atharvbhadange/go-api-template/types"
+	"github.com/gofiber/fiber/v2"
+)
 
 func ErrorHandler(ctx *fiber.Ctx, err error) error {
 	return BuildError(ctx, "Internal Server Error", fiber.StatusInternalServerError, err)
 }
 
 func BuildError(ctx *fiber.Ctx, message interface{}, code int, originalErr error) error {
+	return buildError(ctx, message, T.DefaultMessageCode(code), code, originalErr)
+}
+
+// BuildServiceError responds with serviceErr, including its MessageCode so
+// clients can localize the message, and its RetryAfter as a Retry-After header,
+// which is what clients and proxies act on.
+func BuildServiceError(ctx *fiber.Ctx, serviceErr *T.ServiceError) error {
+	return BuildServiceErrorWithMessage(ctx, serviceErr, serviceErr.Message)
+}
+
+// BuildServiceErrorWithMessage is BuildServiceError with message, such as a map
+// carrying per-item results, sent in place of serviceErr.Message.
+func BuildServiceErrorWithMessage(ctx *fiber.Ctx, serviceErr *T.ServiceError, message interface{}) error {
+	if seconds := int(serviceErr.RetryAfter.Seconds()); seconds > 0 {
+		ctx.Set(fiber.HeaderRetryAfter, strconv.Itoa(seconds))
+	}
+
+	return buildError(ctx, message, serviceErr.MessageCodeOrDefault(), serviceErr.Code, serviceErr.Error)
+}
+
+func buildError(ctx *fiber.Ctx, message interface{}, messageCode string, code int, originalErr error) error {
 	// rollback transaction
 	rollbackCtxTrx(ctx)
 
@@ -21,18 +47,30 @@ func BuildError(ctx *fiber.Ctx, message interface{}, code int, originalErr error
 	}
 
 	return ctx.Status(code).JSON(fiber.Map{
-		"ok":      0,
-		"message": message,
-		"detail": detail,
+		"ok":           0,
+		"message":      message,
+		"message_code": messageCode,
+		"detail":       detail,
 	})
 }
 
 func Success(ctx *fiber.Ctx, data interface{}) error {
-	err := commitCtxTrx(ctx)
+	return SuccessAfterCommit(ctx, data, nil)
+}
 
-	if err != nil {
+// SuccessAfterCommit is Success that also runs afterCommit once the transaction
+// has been committed, before the response is sent. It is not run when the
+// commit fails. Use it for side effects that must only see committed writes.
+func SuccessAfterCommit(ctx *fiber.Ctx, data interface{}, afterCommit func()) error {
+	committed, err := commitCtxTrx(ctx)
+
+	if !committed {
 		return err
 	}
 
+	if afterCommit != nil {
+		afterCommit()
+	}
+
 	return ctx.JSON(data)
 }
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	T "github.com/atharvbhadange/go-api-template/types"
+)
+
+type PaginationHeaders struct {
+	Link       string
+	TotalCount string
+}
+
+// BuildPaginationLinks returns the Link (first, prev, next, last) and X-Total-Count
+// header values for page. prev is omitted on the first page and next on the last.
+func BuildPaginationLinks[E any](baseURL string, page *T.Page[E]) (PaginationHeaders, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return PaginationHeaders{}, err
+	}
+
+	lastPage := page.TotalPages()
+
+	link := func(number int, rel string) string {
+		u := *base
+		query := u.Query()
+		query.Set("page", strconv.Itoa(number))
+		query.Set("page_size", strconv.Itoa(page.PageSize))
+		u.RawQuery = query.Encode()
+		return fmt.Sprintf("<%s>; rel=\"%s\"", u.String(), rel)
+	}
+
+	links := []string{link(1, "first")}
+	if page.Page > 1 {
+		links = append(links, link(min(page.Page-1, lastPage), "prev"))
+	}
+	if page.Page < lastPage {
+		links = append(links, link(page.Page+1, "next"))
+	}
+	links = append(links, link(lastPage, "last"))
+
+	return PaginationHeaders{
+		Link:       strings.Join(links, ", "),
+		TotalCount: strconv.FormatInt(page.Total, 10),
+	}, nil
+}
+
+func SetPaginationHeaders(ctx *fiber.Ctx, headers PaginationHeaders) {
+	ctx.Set("Link", headers.Link)
+	ctx.Set("X-Total-Count", headers.TotalCount)
+}
@@ -0,0 +1,57 @@
+package handler_test
+
+import (
+	"testing"
+
+	H "github.com/atharvbhadange/go-api-template/handler"
+	T "github.com/atharvbhadange/go-api-template/types"
+)
+
+func TestBuildPaginationLinks(t *testing.T) {
+	const (
+		first = `<http://api.test/products?page=1&page_size=10>; rel="first"`
+		prev1 = `<http://api.test/products?page=1&page_size=10>; rel="prev"`
+		prev2 = `<http://api.test/products?page=2&page_size=10>; rel="prev"`
+		prev3 = `<http://api.test/products?page=3&page_size=10>; rel="prev"`
+		next2 = `<http://api.test/products?page=2&page_size=10>; rel="next"`
+		next3 = `<http://api.test/products?page=3&page_size=10>; rel="next"`
+		last1 = `<http://api.test/products?page=1&page_size=10>; rel="last"`
+		last3 = `<http://api.test/products?page=3&page_size=10>; rel="last"`
+	)
+
+	tests := []struct {
+		name      string
+		page      int
+		total     int64
+		wantLink  string
+		wantTotal string
+	}{
+		{name: "first page has no prev", page: 1, total: 25, wantLink: first + ", " + next2 + ", " + last3, wantTotal: "25"},
+		{name: "middle page", page: 2, total: 25, wantLink: first + ", " + prev1 + ", " + next3 + ", " + last3, wantTotal: "25"},
+		{name: "last page has no next", page: 3, total: 25, wantLink: first + ", " + prev2 + ", " + last3, wantTotal: "25"},
+		{name: "only page", page: 1, total: 7, wantLink: first + ", " + last1, wantTotal: "7"},
+		{name: "empty result", page: 1, total: 0, wantLink: first + ", " + last1, wantTotal: "0"},
+		{name: "past the last page points prev at the last", page: 5, total: 25, wantLink: first + ", " + prev3 + ", " + last3, wantTotal: "25"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := &T.Page[int]{Page: tt.page, PageSize: 10, Total: tt.total}
+
+			headers, err := H.BuildPaginationLinks("http://api.test/products", page)
+			if err != nil {
+				t.Fatalf("BuildPaginationLinks() returned %v", err)
+			}
+			if headers.Link != tt.wantLink {
+				t.Fatalf("Link = %s\nwant   %s", headers.Link, tt.wantLink)
+			}
+			if headers.TotalCount != tt.wantTotal {
+				t.Fatalf("X-Total-Count = %s, want %s", headers.TotalCount, tt.wantTotal)
+			}
+		})
+	}
+
+	if _, err := H.BuildPaginationLinks("://bad", &T.Page[int]{Page: 1, PageSize: 10}); err == nil {
+		t.Fatal("BuildPaginationLinks() accepted an invalid base URL")
+	}
+}
@@ -18,19 +18,21 @@ func rollbackCtxTrx(ctx *fiber.Ctx) {
 	}
 }
 
-func commitCtxTrx(ctx *fiber.Ctx) error {
+// commitCtxTrx commits the request's transaction. When that fails it has
+// already sent the error response, returns its result and reports false.
+func commitCtxTrx(ctx *fiber.Ctx) (bool, error) {
 	trx, err := U.StartNewPGTrx(ctx)
 
 	if err != nil {
 		msg := "Unable to get transaction"
-		return BuildError(ctx, msg, fiber.StatusInternalServerError, err)
+		return false, BuildError(ctx, msg, fiber.StatusInternalServerError, err)
 	}
 
 	if trx != nil {
 		if err := trx.Commit(); err != nil {
-			return BuildError(ctx, "Error commit transaction", fiber.StatusInternalServerError, err)
+			return false, BuildError(ctx, "Error commit transaction", fiber.StatusInternalServerError, err)
 		}
 	}
 
-	return nil
+	return true, nil
 }
@@ -2,7 +2,11 @@ package main
 
 import (
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
+	S "github.com/atharvbhadange/go-api-template/api/v1/services"
 	"github.com/atharvbhadange/go-api-template/cmd"
 	"github.com/atharvbhadange/go-api-template/config"
 	"github.com/atharvbhadange/go-api-template/db"
@@ -27,9 +31,29 @@ func main() {
 		log.Fatal(dbErr)
 	}
 
-	defer db.Close()
-
 	app := cmd.InitApp()
 
-	app.Listen(confVars.Port)
+	cmd.WatchMaintenanceSignal()
+
+	// Deferred calls do not run when a signal kills the process, so stop the
+	// server on SIGINT/SIGTERM and close everything once Listen has returned.
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		<-quit
+		if err := app.Shutdown(); err != nil {
+			log.Println(err)
+		}
+	}()
+
+	if err := app.Listen(confVars.Port); err != nil {
+		log.Println(err)
+	}
+
+	if err := S.DefaultProductService.Close(); err != nil {
+		log.Println(err)
+	}
+
+	db.Close()
 }
@@ -1,7 +1,23 @@
 package types
 
+import "time"
+
 type ServiceError struct {
 	Message string
-	Error   error
-	Code    int
+	// Stable, localizable identifier for Message, one of MessageCodes. Empty
+	// falls back to DefaultMessageCode(Code).
+	MessageCode string
+	Error       error
+	Code        int
+	// How long the client should wait before retrying, sent as Retry-After.
+	// 0 sends no header.
+	RetryAfter time.Duration
+}
+
+// MessageCodeOrDefault returns MessageCode, or the generic code for the status.
+func (e *ServiceError) MessageCodeOrDefault() string {
+	if e.MessageCode != "" {
+		return e.MessageCode
+	}
+	return DefaultMessageCode(e.Code)
 }
@@ -0,0 +1,114 @@
+package types
+
+import "net/http"
+
+// Stable identifiers for ServiceError messages, so clients can localize them
+// without matching on the English text. Add new codes to MessageCodes too.
+const (
+	MsgProductNotFound            = "product.not_found"
+	MsgProductIDInvalid           = "product.id_invalid"
+	MsgProductNameRequired        = "product.name_required"
+	MsgProductNameTooLong         = "product.name_too_long"
+	MsgProductNameInvalid         = "product.name_invalid"
+	MsgProductNameConflict        = "product.name_conflict"
+	MsgProductDescriptionTooLong  = "product.description_too_long"
+	MsgProductDescriptionInvalid  = "product.description_invalid"
+	MsgProductDescriptionRequired = "product.description_required"
+	MsgProductPriceRequired       = "product.price_required"
+	MsgProductPriceInvalid        = "product.price_invalid"
+	MsgProductPriceNegative       = "product.price_negative"
+	MsgProductPriceScale          = "product.price_scale"
+	MsgProductPriceChangeTooLarge = "product.price_change_too_large"
+	MsgCurrencyUnknown            = "currency.unknown"
+	MsgPaginationInvalid          = "pagination.invalid"
+	MsgSortInvalid                = "list.sort_invalid"
+	MsgFieldUnknown               = "request.unknown_field"
+	MsgFieldsRequired             = "request.fields_required"
+	MsgIDsRequired                = "request.ids_required"
+	MsgTooManyIDs                 = "request.too_many_ids"
+	MsgPrefixRequired             = "request.prefix_required"
+	MsgTimeRangeInvalid           = "request.time_range_invalid"
+	MsgBucketSizeInvalid          = "request.bucket_size_invalid"
+	MsgDocumentInvalid            = "request.document_invalid"
+	MsgBulkItemsInvalid           = "bulk.items_invalid"
+	MsgDeleteCountMismatch        = "bulk.delete_count_mismatch"
+	MsgImportDocumentInvalid      = "import.document_invalid"
+	MsgAuditActionInvalid         = "audit.action_invalid"
+	MsgUserIDRequired             = "audit.user_id_required"
+	MsgMaintenance                = "service.maintenance"
+
+	// Used when a ServiceError sets no MessageCode.
+	MsgBadRequest  = "error.bad_request"
+	MsgNotFound    = "error.not_found"
+	MsgConflict    = "error.conflict"
+	MsgInvalid     = "error.unprocessable"
+	MsgUnavailable = "error.unavailable"
+	MsgTimeout     = "error.timeout"
+	MsgInternal    = "error.internal"
+	MsgGeneric     = "error.generic"
+)
+
+// MessageCodes lists every code a response can carry.
+var MessageCodes = []string{
+	MsgProductNotFound,
+	MsgProductIDInvalid,
+	MsgProductNameRequired,
+	MsgProductNameTooLong,
+	MsgProductNameInvalid,
+	MsgProductNameConflict,
+	MsgProductDescriptionTooLong,
+	MsgProductDescriptionInvalid,
+	MsgProductDescriptionRequired,
+	MsgProductPriceRequired,
+	MsgProductPriceInvalid,
+	MsgProductPriceNegative,
+	MsgProductPriceScale,
+	MsgProductPriceChangeTooLarge,
+	MsgCurrencyUnknown,
+	MsgPaginationInvalid,
+	MsgSortInvalid,
+	MsgFieldUnknown,
+	MsgFieldsRequired,
+	MsgIDsRequired,
+	MsgTooManyIDs,
+	MsgPrefixRequired,
+	MsgTimeRangeInvalid,
+	MsgBucketSizeInvalid,
+	MsgDocumentInvalid,
+	MsgBulkItemsInvalid,
+	MsgDeleteCountMismatch,
+	MsgImportDocumentInvalid,
+	MsgAuditActionInvalid,
+	MsgUserIDRequired,
+	MsgMaintenance,
+	MsgBadRequest,
+	MsgNotFound,
+	MsgConflict,
+	MsgInvalid,
+	MsgUnavailable,
+	MsgTimeout,
+	MsgInternal,
+	MsgGeneric,
+}
+
+// DefaultMessageCode is the generic code for an HTTP status, used for errors
+// that carry no specific code, such as database failures.
+func DefaultMessageCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return MsgBadRequest
+	case http.StatusNotFound:
+		return MsgNotFound
+	case http.StatusConflict:
+		return MsgConflict
+	case http.StatusUnprocessableEntity:
+		return MsgInvalid
+	case http.StatusServiceUnavailable:
+		return MsgUnavailable
+	case http.StatusGatewayTimeout:
+		return MsgTimeout
+	case 0, http.StatusInternalServerError:
+		return MsgInternal
+	}
+	return MsgGeneric
+}
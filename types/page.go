@@ -0,0 +1,18 @@
+package types
+
+type Page[E any] struct {
+	Items    []E   `json:"items"`
+	Page     int   `json:"page"`
+	PageSize int   `json:"page_size"`
+	Total    int64 `json:"total"`
+
+	// Total is an estimate from table statistics rather than an exact count.
+	TotalApproximate bool `json:"total_approximate"`
+}
+
+func (p *Page[E]) TotalPages() int {
+	if p.PageSize <= 0 || p.Total <= 0 {
+		return 1
+	}
+	return int((p.Total + int64(p.PageSize) - 1) / int64(p.PageSize))
+}
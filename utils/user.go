@@ -0,0 +1,18 @@
+package utils
+
+import "context"
+
+type userIDKey struct{}
+
+// WithUserID stores the id of the acting user on ctx, for auth middleware to set
+// on ctx.UserContext() before the request reaches the services.
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// UserIDFromContext returns the acting user id, or false for unauthenticated and
+// system calls.
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	userID, ok := ctx.Value(userIDKey{}).(string)
+	return userID, ok && userID != ""
+}